@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	ncli "jobflow.local/internal/notion"
+	"jobflow.local/internal/store"
+)
+
+// runImport implements `jobflow import`: copy the rows of an existing Notion
+// tracker into SQLite. Pages already linked to an application are skipped,
+// so it is safe to run again.
+func runImport(ctx context.Context, nc *ncli.Client, st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "count what would be imported without writing")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: jobflow import [-dry-run]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	res, err := ncli.NewImporter(nc, st).Run(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "imported"
+	if *dryRun {
+		verb = "to import"
+	}
+	fmt.Printf("Notion pages read:     %d\n", res.Pages)
+	fmt.Printf("Applications %-9s %d\n", verb+":", res.Imported)
+	fmt.Printf("Already linked:        %d\n", res.Skipped)
+	return nil
+}
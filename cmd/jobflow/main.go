@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 
+	"jobflow.local/internal/ai"
 	"jobflow.local/internal/api"
 	ncli "jobflow.local/internal/notion"
 	"jobflow.local/internal/store"
@@ -27,6 +30,115 @@ func normalizeNotionID(id string) string {
 	return strings.ReplaceAll(id, "-", "")
 }
 
+// enricherName describes the endpoint and model ai.New picked.
+func enricherName(cfg ai.Config) string {
+	base, model := cfg.BaseURL, cfg.Model
+	if base == "" {
+		base = ai.DefaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = ai.DefaultModel
+	}
+	return model + " at " + base
+}
+
+// logMigrations prints which schema versions are applied and which are
+// about to run.
+func logMigrations(st *store.Store) {
+	statuses, err := st.Migrations(context.Background())
+	if err != nil {
+		log.Fatalf("read schema_migrations: %v", err)
+	}
+
+	var applied, pending []string
+	for _, m := range statuses {
+		v := fmt.Sprintf("%d (%s)", m.Version, m.Name)
+		if m.Applied() {
+			applied = append(applied, v)
+		} else {
+			pending = append(pending, v)
+		}
+	}
+	if len(applied) == 0 {
+		applied = append(applied, "none")
+	}
+	if len(pending) == 0 {
+		pending = append(pending, "none")
+	}
+	log.Println("Schema applied:               ", strings.Join(applied, ", "))
+	log.Println("Schema pending:               ", strings.Join(pending, ", "))
+}
+
+// checkNotionSchema reports tracker properties that don't match the mapping
+// and, when provision is set, adds the missing ones.
+func checkNotionSchema(ctx context.Context, nc *ncli.Client, provision bool) {
+	report, err := nc.CheckSchema(ctx)
+	if err != nil {
+		log.Fatalf("Notion schema check failed: %v", err)
+	}
+	if report.OK() {
+		log.Println("Notion schema OK.")
+		return
+	}
+
+	for _, issue := range report.Issues {
+		log.Println("Notion schema:", issue)
+	}
+	if !provision {
+		log.Println("Set NOTION_AUTO_PROVISION=true to add missing properties and options.")
+		return
+	}
+
+	unfixed, err := nc.ProvisionSchema(ctx, report)
+	if err != nil {
+		log.Fatalf("Notion schema provisioning failed: %v", err)
+	}
+	log.Printf("Notion schema provisioned (%d issues left to fix by hand).", len(unfixed))
+	for _, issue := range unfixed {
+		log.Println("Notion schema (manual):", issue)
+	}
+}
+
+// runLocalCommand dispatches a subcommand that only needs SQLite, so it
+// works offline and without Notion credentials; it reports false for any
+// other command.
+func runLocalCommand(ctx context.Context, st *store.Store, args []string) bool {
+	var err error
+	switch args[0] {
+	case "salaries":
+		err = runSalaries(ctx, st, args[1:])
+	case "normalize":
+		err = runNormalize(ctx, st, args[1:])
+	default:
+		return false
+	}
+	exitOnCommandError(args[0], err)
+	return true
+}
+
+// runCommand dispatches a subcommand that talks to Notion; it reports false
+// for an unknown one.
+func runCommand(ctx context.Context, nc *ncli.Client, st *store.Store, args []string) bool {
+	var err error
+	switch args[0] {
+	case "reconcile":
+		err = runReconcile(ctx, nc, st, args[1:])
+	case "import":
+		err = runImport(ctx, nc, st, args[1:])
+	default:
+		return false
+	}
+	exitOnCommandError(args[0], err)
+	return true
+}
+
+func exitOnCommandError(cmd string, err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+		os.Exit(1)
+	}
+}
+
 func main() {
 	_ = godotenv.Load()
 
@@ -34,6 +146,25 @@ func main() {
 	rawNotionDBID := os.Getenv("NOTION_DB_ID")
 	sqlitePath := os.Getenv("JOBFLOW_DB")
 	port := os.Getenv("PORT")
+	syncInterval := os.Getenv("NOTION_SYNC_INTERVAL")
+	mappingFile := os.Getenv("NOTION_MAPPING_FILE")
+	autoProvision := os.Getenv("NOTION_AUTO_PROVISION") == "true"
+	aiWorkers := 2
+	if v := os.Getenv("AI_WORKERS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			log.Fatalf("invalid AI_WORKERS %q", v)
+		}
+		aiWorkers = n
+	}
+	var hoursPerYear float64
+	if v := os.Getenv("SALARY_HOURS_PER_YEAR"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n <= 0 {
+			log.Fatalf("invalid SALARY_HOURS_PER_YEAR %q", v)
+		}
+		hoursPerYear = n
+	}
 
 	if port == "" {
 		// You’re already using 8081, keep that.
@@ -42,10 +173,9 @@ func main() {
 	if sqlitePath == "" {
 		sqlitePath = "jobflow.sqlite"
 	}
-	if rawNotionToken == "" || rawNotionDBID == "" {
-		log.Fatal("NOTION_TOKEN and NOTION_DB_ID must be set in your environment (.env)")
+	if syncInterval == "" {
+		syncInterval = "5m"
 	}
-
 	notionDBID := normalizeNotionID(rawNotionDBID)
 
 	log.Println("=== JobFlow Startup Sanity ===")
@@ -54,6 +184,10 @@ func main() {
 	log.Println("Using Notion Token (masked): ", mask(rawNotionToken))
 	log.Println("SQLite file:                  ", sqlitePath)
 	log.Println("HTTP port:                    ", port)
+	log.Println("Notion sync interval:         ", syncInterval)
+	if mappingFile != "" {
+		log.Println("Notion mapping file:          ", mappingFile)
+	}
 	log.Println("==============================")
 
 	// SQLite
@@ -64,22 +198,105 @@ func main() {
 	defer db.Close()
 
 	st := store.New(db)
+	st.HoursPerYear = hoursPerYear
+	logMigrations(st)
 	if err := st.Migrate(context.Background()); err != nil {
 		log.Fatalf("migrate: %v", err)
 	}
 	log.Println("SQLite ready at:", sqlitePath)
 
+	// Local subcommands (e.g. `jobflow salaries`) run once and exit, before
+	// any Notion setup.
+	if args := os.Args[1:]; len(args) > 0 && runLocalCommand(context.Background(), st, args) {
+		return
+	}
+
+	if rawNotionToken == "" || rawNotionDBID == "" {
+		log.Fatal("NOTION_TOKEN and NOTION_DB_ID must be set in your environment (.env)")
+	}
+
 	// Notion client + ping
-	nc := ncli.New(rawNotionToken, notionDBID)
+	var notionOpts []ncli.Option
+	if mappingFile != "" {
+		m, err := ncli.LoadMapping(mappingFile)
+		if err != nil {
+			log.Fatalf("load Notion mapping: %v", err)
+		}
+		notionOpts = append(notionOpts, ncli.WithMapping(m))
+	}
+	nc := ncli.New(rawNotionToken, notionDBID, notionOpts...)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := nc.Ping(ctx); err != nil {
 		log.Fatalf("Notion ping failed: %v", err)
 	}
 	log.Println("Notion connection OK.")
+	checkNotionSchema(ctx, nc, autoProvision)
+
+	// Notion subcommands (e.g. `jobflow reconcile`) run once and exit.
+	if args := os.Args[1:]; len(args) > 0 {
+		if !runCommand(context.Background(), nc, st, args) {
+			log.Fatalf("unknown command %q (available: reconcile, import, salaries, normalize)", args[0])
+		}
+		return
+	}
+
+	// Notion → SQLite sync ("0" disables it)
+	interval, err := time.ParseDuration(syncInterval)
+	if err != nil {
+		log.Fatalf("invalid NOTION_SYNC_INTERVAL %q: %v", syncInterval, err)
+	}
+	if interval > 0 {
+		go ncli.NewSyncer(nc, st, interval).Run(context.Background())
+	}
+
+	// SQLite → Notion writes, retried until delivered
+	ob := ncli.NewOutbox(nc, st, 30*time.Second)
+	go ob.Run(context.Background())
+
+	// LLM enrichment (off unless an API key or AI_BASE_URL is set)
+	aiCfg, err := ai.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var (
+		eq        *ai.Queue
+		fitter    *ai.Fitter
+		drafter   ai.Drafter
+		extractor ai.ContactExtractor
+	)
+	if enricher := ai.New(aiCfg); enricher == nil {
+		log.Println("AI enrichment disabled (set AI_API_KEY/OPENAI_API_KEY or AI_BASE_URL).")
+	} else {
+		log.Printf("AI enrichment via %s (%d workers)", enricherName(aiCfg), aiWorkers)
+		eq = ai.NewQueue(enricher, st, aiWorkers)
+		go eq.Run(context.Background())
+
+		// Resume fit scores: a pass every 5 minutes and after each new job or resume
+		fitter = ai.NewFitter(enricher, st, 5*time.Minute)
+		go fitter.Run(context.Background())
+
+		drafter = enricher
+		extractor = enricher
+	}
+
+	// Job embeddings for similar jobs and duplicate warnings (local unless an
+	// LLM is configured or AI_EMBEDDINGS says otherwise)
+	embedder, err := ai.NewEmbedder(aiCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var index *ai.Index
+	if embedder == nil {
+		log.Println("Job embeddings disabled (AI_EMBEDDINGS=off).")
+	} else {
+		log.Printf("Job embeddings with %s", embedder.Model())
+		index = ai.NewIndex(embedder, st, 10*time.Minute)
+		go index.Run(context.Background())
+	}
 
 	// HTTP API
-	s := api.New(st, nc)
+	s := api.New(st, nc, ob, eq, fitter, drafter, extractor, index)
 	addr := ":" + port
 	log.Println("HTTP listening on", addr)
 	if err := s.Listen(addr); err != nil {
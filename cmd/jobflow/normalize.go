@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// runNormalize implements `jobflow normalize`: apply domain.NormalizeJob to
+// jobs saved before it existed (or before the gazetteer learned their
+// place). Changed jobs get their Notion pages updated by the outbox, so the
+// next server run cleans up the tracker's Work Mode values.
+func runNormalize(ctx context.Context, st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("normalize", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "list the changes without writing them")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: jobflow normalize [-dry-run]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	jobs, err := st.ListJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	changed, queued := 0, 0
+	for _, job := range jobs {
+		n := job
+		domain.NormalizeJob(&n)
+		if n.WorkMode == job.WorkMode && n.Place == job.Place {
+			continue
+		}
+		changed++
+		fmt.Printf("job %d: %q / %q -> %s\n", job.ID, job.WorkMode, job.Location, describePlace(n))
+		if *dryRun {
+			continue
+		}
+		q, err := st.SaveNormalizedJob(ctx, n)
+		if err != nil {
+			return fmt.Errorf("job %d: %w", job.ID, err)
+		}
+		queued += q
+	}
+
+	verb := "updated"
+	if *dryRun {
+		verb = "to update"
+	}
+	fmt.Printf("Jobs read:             %d\n", len(jobs))
+	fmt.Printf("Jobs %-17s %d\n", verb+":", changed)
+	fmt.Printf("Notion pages queued:   %d\n", queued)
+	return nil
+}
+
+// describePlace renders a normalized job for the change list, e.g.
+// `"Remote" (US; remote from US)`.
+func describePlace(job domain.Job) string {
+	var parts []string
+	for _, p := range []string{job.Place.City, job.Place.Region, job.Place.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	s := fmt.Sprintf("%q (%s", job.WorkMode, strings.Join(parts, ", "))
+	if job.Place.RemoteScope != "" {
+		s += "; remote from " + job.Place.RemoteScope
+	}
+	return s + ")"
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	ncli "jobflow.local/internal/notion"
+	"jobflow.local/internal/store"
+)
+
+// runReconcile implements `jobflow reconcile`: report drift between SQLite
+// and the Notion tracker and, with -apply, fix it.
+func runReconcile(ctx context.Context, nc *ncli.Client, st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	apply := fs.Bool("apply", false, "fix the drift instead of only reporting it")
+	restore := fs.Bool("restore-archived", false, "with -apply, restore linked pages archived in Notion")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: jobflow reconcile [-apply] [-restore-archived]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ob := ncli.NewOutbox(nc, st, 0)
+	rec := ncli.NewReconciler(nc, st, ob)
+
+	report, err := rec.Plan(ctx)
+	if err != nil {
+		return err
+	}
+	printReconcileReport(report)
+	if report.OK() || !*apply {
+		if !report.OK() {
+			fmt.Println("\nDry run: nothing changed. Run with -apply to fix.")
+		}
+		return nil
+	}
+
+	res, err := rec.Apply(ctx, report, *restore)
+	if err != nil {
+		return err
+	}
+	fmt.Println("\nApplied:")
+	fmt.Printf("  linked to existing pages:    %d\n", res.Linked)
+	fmt.Printf("  pages created:               %d\n", res.Created)
+	fmt.Printf("  left in the outbox:          %d\n", res.Queued)
+	fmt.Printf("  unlinked from missing pages: %d\n", res.Unlinked)
+	fmt.Printf("  archived pages restored:     %d\n", res.Restored)
+	return nil
+}
+
+func printReconcileReport(r ncli.ReconcileReport) {
+	if r.OK() {
+		fmt.Println("SQLite and Notion are in sync.")
+		return
+	}
+
+	fmt.Printf("Applications without a Notion page: %d\n", len(r.Unlinked))
+	for _, it := range r.Unlinked {
+		fmt.Printf("  #%d %s — %s (%s)\n", it.Application.ID, it.Job.Title, it.Job.Company, it.Job.URL)
+	}
+
+	fmt.Printf("Notion pages with no application: %d\n", len(r.Orphans))
+	for _, p := range r.Orphans {
+		fmt.Printf("  %s %s — %s (%s)\n", p.ID, p.Title, p.Company, p.URL)
+	}
+
+	fmt.Printf("Linked pages archived in Notion: %d\n", len(r.Archived))
+	for _, sp := range r.Archived {
+		fmt.Printf("  %s ← applications %v\n", sp.PageID, sp.ApplicationIDs)
+	}
+
+	fmt.Printf("Linked pages missing from the tracker: %d\n", len(r.Missing))
+	for _, sp := range r.Missing {
+		fmt.Printf("  %s ← applications %v\n", sp.PageID, sp.ApplicationIDs)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"jobflow.local/internal/store"
+)
+
+// runSalaries implements `jobflow salaries`: rebuild the structured salary of
+// every job, e.g. for jobs saved before salaries were parsed or after
+// changing SALARY_HOURS_PER_YEAR. Inferred salaries are kept.
+func runSalaries(ctx context.Context, st *store.Store, args []string) error {
+	fs := flag.NewFlagSet("salaries", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: jobflow salaries")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	n, err := st.ReparseSalaries(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Jobs with a salary range: %d\n", n)
+	return nil
+}
@@ -1,11 +1,8 @@
 package ai
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -17,35 +14,80 @@ type EnrichedJob struct {
 	Skills       []string `json:"skills"`
 	TailoredNote string   `json:"tailored_note"`
 	RawSnippet   string   `json:"raw_snippet"`
+	Salary       string   `json:"salary"` // pay as the description states it, "" if it doesn't
+
+	// Provenance, filled in by the Enricher.
+	Model         string `json:"-"`
+	PromptVersion string `json:"-"`
 }
 
-// Minimal types to talk to /v1/chat/completions.
-type chatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+// enrichPromptVersion identifies enrichPrompt in stored enrichments. Bump it
+// whenever the prompt or schema changes so older results can be told apart.
+const enrichPromptVersion = "enrich-3"
+
+// Enricher turns a job description into a structured EnrichedJob.
+type Enricher interface {
+	Enrich(ctx context.Context, rawText, role, company string) (EnrichedJob, error)
 }
 
-type chatRequest struct {
-	Model    string        `json:"model"`
-	Messages []chatMessage `json:"messages"`
+// Defaults used when the matching setting is empty.
+const (
+	DefaultOpenAIBaseURL  = "https://api.openai.com/v1"
+	DefaultModel          = "gpt-4o-mini"
+	DefaultTimeout        = 15 * time.Second
+	DefaultEmbeddingModel = "text-embedding-3-small"
+)
+
+// Config selects and configures the LLM used for enrichment.
+type Config struct {
+	BaseURL string        // OpenAI-compatible API root, e.g. http://localhost:11434/v1
+	Model   string        // model name as the server knows it
+	APIKey  string        // optional for local servers
+	Timeout time.Duration // per request
+
+	EmbeddingProvider string // EmbeddingsAPI, EmbeddingsLocal or EmbeddingsOff; see NewEmbedder
+	EmbeddingModel    string // for EmbeddingsAPI
 }
 
-type chatResponse struct {
-	Choices []struct {
-		Message chatMessage `json:"message"`
-	} `json:"choices"`
+// ConfigFromEnv reads AI_BASE_URL, AI_MODEL, AI_API_KEY (falling back to
+// OPENAI_API_KEY), AI_TIMEOUT, AI_EMBEDDINGS and AI_EMBEDDING_MODEL.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		BaseURL:           os.Getenv("AI_BASE_URL"),
+		Model:             os.Getenv("AI_MODEL"),
+		APIKey:            os.Getenv("AI_API_KEY"),
+		EmbeddingProvider: os.Getenv("AI_EMBEDDINGS"),
+		EmbeddingModel:    os.Getenv("AI_EMBEDDING_MODEL"),
+	}
+	if cfg.APIKey == "" {
+		cfg.APIKey = os.Getenv("OPENAI_API_KEY")
+	}
+	if v := os.Getenv("AI_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid AI_TIMEOUT %q: %w", v, err)
+		}
+		cfg.Timeout = d
+	}
+	return cfg, nil
 }
 
-// EnrichJobWithLLM calls OpenAI once and tries to turn the
-// job description into a structured EnrichedJob.
-func EnrichJobWithLLM(rawText, role, company string) (EnrichedJob, error) {
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if apiKey == "" {
-		return EnrichedJob{}, fmt.Errorf("missing OPENAI_API_KEY")
+// New returns the client cfg describes: an OpenAI-compatible server when
+// BaseURL is set, OpenAI itself when only an API key is, and nil (AI features
+// off) when neither is. It is both an Enricher and a FitScorer.
+func New(cfg Config) *ChatEnricher {
+	switch {
+	case cfg.BaseURL != "":
+		return NewOpenAICompatible(cfg)
+	case cfg.APIKey != "":
+		return NewOpenAI(cfg)
 	}
+	return nil
+}
 
-	// 1) Build the prompt
-	prompt := fmt.Sprintf(`
+// enrichPrompt is the single user message sent to the model.
+func enrichPrompt(rawText, role, company string) string {
+	return fmt.Sprintf(`
 You are an AI assistant for job seekers.
 
 Summarize the job description and extract useful fields.
@@ -56,75 +98,34 @@ Return STRICT JSON only, with this exact shape:
   "summary": "3-6 sentence summary of the role",
   "skills": ["skill1", "skill2", "..."],
   "tailored_note": "short advice for this candidate (resume tweaks, strategy, etc.)",
-  "raw_snippet": "most important 250 characters from the job description"
+  "raw_snippet": "most important 250 characters from the job description",
+  "salary": "the pay exactly as stated, e.g. \"$120k-$150k per year\", or \"\""
 }
 
-Do NOT add any extra keys or text outside the JSON.
+"skills" has at most %d items. "salary" must come from the description
+itself; leave it empty rather than guess. Do NOT add any extra keys or text
+outside the JSON.
 
 JOB TITLE: %s
 COMPANY: %s
 
 DESCRIPTION:
 %s
-`, role, company, rawText)
-
-	reqPayload := chatRequest{
-		// You can switch to "gpt-4.1-mini" or another model if you prefer.
-		Model: "gpt-4o-mini",
-		Messages: []chatMessage{
-			{Role: "user", Content: prompt},
-		},
-	}
-
-	bodyBytes, err := json.Marshal(reqPayload)
-	if err != nil {
-		return EnrichedJob{}, fmt.Errorf("marshal chat request: %w", err)
-	}
-
-	// 2) Build HTTP request
-	httpReq, err := http.NewRequest(
-		http.MethodPost,
-		"https://api.openai.com/v1/chat/completions",
-		bytes.NewReader(bodyBytes),
-	)
-	if err != nil {
-		return EnrichedJob{}, fmt.Errorf("create HTTP request: %w", err)
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// 3) Send it
-	client := &http.Client{Timeout: 15 * time.Second}
-	httpResp, err := client.Do(httpReq)
-	if err != nil {
-		return EnrichedJob{}, fmt.Errorf("call OpenAI: %w", err)
-	}
-	defer httpResp.Body.Close()
-
-	if httpResp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(httpResp.Body)
-		return EnrichedJob{}, fmt.Errorf("OpenAI HTTP %d: %s", httpResp.StatusCode, strings.TrimSpace(string(b)))
-	}
-
-	// 4) Decode the OpenAI chat response
-	var chatResp chatResponse
-	if err := json.NewDecoder(httpResp.Body).Decode(&chatResp); err != nil {
-		return EnrichedJob{}, fmt.Errorf("decode chat response: %w", err)
-	}
-
-	if len(chatResp.Choices) == 0 {
-		return EnrichedJob{}, fmt.Errorf("no choices returned from OpenAI")
-	}
-
-	rawContent := strings.TrimSpace(chatResp.Choices[0].Message.Content)
+`, maxSkills, role, company, rawText)
+}
 
-	// 5) Try to parse the model's JSON into our EnrichedJob struct
-	var ej EnrichedJob
-	if err := json.Unmarshal([]byte(rawContent), &ej); err != nil {
-		// Fallback: if the model didn't return valid JSON, just stuff the text into RawSnippet
-		ej.RawSnippet = rawContent
+// repairPrompt asks the model to fix a reply that failed validation
+// against schema.
+func repairPrompt(problems []string, schema map[string]any) string {
+	var keys []string
+	if required, ok := schema["required"].([]string); ok {
+		for _, k := range required {
+			keys = append(keys, `"`+k+`"`)
+		}
 	}
+	return fmt.Sprintf(`Your reply did not match the required JSON shape:
+- %s
 
-	return ej, nil
+Reply again with ONLY the corrected JSON object, with exactly the keys
+%s.`, strings.Join(problems, "\n- "), strings.Join(keys, ", "))
 }
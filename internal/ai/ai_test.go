@@ -0,0 +1,36 @@
+package ai
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// newTestStore opens a migrated store on a fresh database file.
+func newTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	db, err := store.OpenSQLite(filepath.Join(t.TempDir(), "jobflow.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	st := store.New(db)
+	if err := st.Migrate(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return st
+}
+
+// saveTestJob saves job with a new application and returns it with its id.
+func saveTestJob(t *testing.T, st *store.Store, job domain.Job) domain.Job {
+	t.Helper()
+	app := domain.Application{Stage: "Applied"}
+	if err := st.UpsertJobAndApplication(context.Background(), &job, &app, nil, domain.SourceAPI); err != nil {
+		t.Fatalf("save job: %v", err)
+	}
+	return job
+}
@@ -0,0 +1,295 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// maxContacts caps how many contacts one description can yield.
+const maxContacts = 5
+
+// Confidence given to contacts found by pattern matching.
+const (
+	regexEmailConfidence = 0.6 // a personal-looking address
+	regexInboxConfidence = 0.3 // careers@, jobs@ and the like
+	regexNameConfidence  = 0.4
+)
+
+// ContactExtractor finds the recruiters, hiring managers and contact emails
+// a job description names.
+type ContactExtractor interface {
+	ExtractContacts(ctx context.Context, description, company string) ([]domain.Contact, error)
+}
+
+// extractedContactsSchema is the JSON schema of the extraction reply.
+var extractedContactsSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"contacts": map[string]any{
+			"type":     "array",
+			"maxItems": maxContacts,
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":       map[string]any{"type": "string"},
+					"email":      map[string]any{"type": "string"},
+					"role":       map[string]any{"type": "string"},
+					"confidence": map[string]any{"type": "number", "minimum": 0, "maximum": 1},
+				},
+				"required":             []string{"name", "email", "role", "confidence"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"contacts"},
+	"additionalProperties": false,
+}
+
+// ExtractContacts asks the model for the people a description names, with
+// the same repair turn as Enrich.
+func (e *ChatEnricher) ExtractContacts(ctx context.Context, description, company string) ([]domain.Contact, error) {
+	var contacts []domain.Contact
+	err := e.structured(ctx, contactsPrompt(description, company), "job_contacts", extractedContactsSchema,
+		func(content string) []string {
+			var problems []string
+			contacts, problems = parseExtractedContacts(content)
+			return problems
+		})
+	return contacts, err
+}
+
+// contactsPrompt is the single user message sent to the model.
+func contactsPrompt(description, company string) string {
+	return fmt.Sprintf(`
+You are an AI assistant for job seekers.
+
+List the people or inboxes this job description names as a way to reach the
+company about the role: recruiters, hiring managers, talent partners,
+contact emails.
+
+Return STRICT JSON only, with this exact shape:
+
+{
+  "contacts": [
+    {"name": "full name or empty", "email": "address or empty", "role": "e.g. Recruiter", "confidence": 0.0-1.0}
+  ]
+}
+
+"confidence" is how sure you are the person is a real contact for this role.
+Only include people actually named in the text, at most %d; return an empty
+list if there are none. Do NOT add any extra keys or text outside the JSON.
+
+COMPANY: %s
+
+DESCRIPTION:
+%s
+`, maxContacts, company, description)
+}
+
+// parseExtractedContacts decodes a reply and checks it against
+// extractedContactsSchema.
+func parseExtractedContacts(content string) ([]domain.Contact, []string) {
+	var reply struct {
+		Contacts *[]struct {
+			Name       *string  `json:"name"`
+			Email      *string  `json:"email"`
+			Role       *string  `json:"role"`
+			Confidence *float64 `json:"confidence"`
+		} `json:"contacts"`
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(stripCodeFence(content))))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reply); err != nil {
+		return nil, []string{"not a JSON object of the expected shape: " + err.Error()}
+	}
+	if dec.More() {
+		return nil, []string{"extra text after the JSON object"}
+	}
+	if reply.Contacts == nil {
+		return nil, []string{`"contacts" is required`}
+	}
+	if n := len(*reply.Contacts); n > maxContacts {
+		return nil, []string{fmt.Sprintf(`"contacts" has %d items, at most %d allowed`, n, maxContacts)}
+	}
+
+	var (
+		contacts []domain.Contact
+		problems []string
+	)
+	for i, c := range *reply.Contacts {
+		if c.Name == nil || c.Email == nil || c.Role == nil || c.Confidence == nil {
+			problems = append(problems, fmt.Sprintf(`contact %d needs "name", "email", "role" and "confidence"`, i))
+			continue
+		}
+		if *c.Confidence < 0 || *c.Confidence > 1 {
+			problems = append(problems, fmt.Sprintf(`contact %d has confidence %v, expected 0 to 1`, i, *c.Confidence))
+			continue
+		}
+		contact := domain.Contact{
+			Name:       strings.TrimSpace(*c.Name),
+			Email:      strings.TrimSpace(*c.Email),
+			Role:       strings.TrimSpace(*c.Role),
+			Confidence: *c.Confidence,
+			Source:     domain.ContactSourceAI,
+		}
+		if contact.Email != "" && !emailPattern.MatchString(contact.Email) {
+			contact.Email = ""
+		}
+		if contact.Name == "" && contact.Email == "" {
+			continue
+		}
+		contacts = append(contacts, contact)
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return contacts, nil
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+	// A role keyword followed by a capitalized two- or three-word name, e.g.
+	// "Recruiter: Jane Doe" or "reach out to Jane Doe".
+	namePattern = regexp.MustCompile(`(?:([Rr]ecruiter|[Hh]iring [Mm]anager|[Tt]alent [Pp]artner|[Tt]alent [Aa]cquisition(?: [Pp]artner)?)|[Cc]ontact|[Rr]each out to)\s*(?:[:\-–—]|is)?\s*([A-Z][A-Za-z'’]*[a-z](?:[ -][A-Z][A-Za-z'’]*[a-z]){1,2})\b`)
+
+	// Local parts of shared inboxes rather than people.
+	inboxLocalParts = []string{"careers", "jobs", "job", "hr", "recruiting", "recruitment", "talent", "hiring", "apply", "info"}
+
+	// Capitalized words that follow "Contact" in headings but aren't names.
+	nameStopWords = []string{"us", "our", "with", "the", "and", "for", "form", "information", "details", "center", "team", "support", "customers", "page"}
+)
+
+// RegexContacts finds contact emails and "Recruiter: Name" style mentions in
+// a description without the LLM. It is the fallback when no model is
+// configured or the model fails.
+func RegexContacts(description string) []domain.Contact {
+	var contacts []domain.Contact
+	seen := map[string]bool{}
+
+	for _, email := range emailPattern.FindAllString(description, -1) {
+		local := strings.ToLower(email[:strings.IndexByte(email, '@')])
+		if seen[strings.ToLower(email)] || strings.Contains(local, "noreply") || strings.Contains(local, "no-reply") {
+			continue
+		}
+		seen[strings.ToLower(email)] = true
+		c := domain.Contact{Email: email, Confidence: regexEmailConfidence, Source: domain.ContactSourceRegex}
+		for _, p := range inboxLocalParts {
+			if local == p {
+				c.Role = "Shared inbox"
+				c.Confidence = regexInboxConfidence
+				break
+			}
+		}
+		contacts = append(contacts, c)
+	}
+
+names:
+	for _, m := range namePattern.FindAllStringSubmatch(description, -1) {
+		if seen[strings.ToLower(m[2])] {
+			continue
+		}
+		seen[strings.ToLower(m[2])] = true
+		for _, w := range strings.FieldsFunc(m[2], func(r rune) bool { return r == ' ' || r == '-' }) {
+			if slices.Contains(nameStopWords, strings.ToLower(w)) {
+				continue names
+			}
+		}
+		c := domain.Contact{
+			Name:       m[2],
+			Role:       titleCase(m[1]),
+			Confidence: regexNameConfidence,
+			Source:     domain.ContactSourceRegex,
+		}
+
+		// jane.doe@ or jdoe@ next to "Jane Doe" is the same person.
+		for i, e := range contacts {
+			if e.Name == "" && e.Role == "" && emailMatchesName(e.Email, c.Name) {
+				c.Email = e.Email
+				c.Confidence = regexEmailConfidence
+				contacts = slices.Delete(contacts, i, i+1)
+				break
+			}
+		}
+		contacts = append(contacts, c)
+	}
+
+	if len(contacts) > maxContacts {
+		contacts = contacts[:maxContacts]
+	}
+	return contacts
+}
+
+// ExtractContacts runs x, when set, and adds what RegexContacts finds that
+// the model missed. If the model fails the regex results are still returned,
+// along with its error.
+func ExtractContacts(ctx context.Context, x ContactExtractor, job domain.Job) ([]domain.Contact, error) {
+	found := RegexContacts(job.Description)
+	if x == nil {
+		return found, nil
+	}
+
+	contacts, err := x.ExtractContacts(ctx, job.Description, job.Company)
+	if err != nil {
+		return found, err
+	}
+
+	seen := map[string]bool{}
+	for _, c := range contacts {
+		seen[strings.ToLower(c.Email)] = c.Email != ""
+		seen[strings.ToLower(c.Name)] = c.Name != ""
+	}
+	for _, c := range found {
+		if seen[strings.ToLower(c.Email)] || seen[strings.ToLower(c.Name)] {
+			continue
+		}
+		contacts = append(contacts, c)
+	}
+	return contacts, nil
+}
+
+// emailMatchesName reports whether the local part of email spells name as
+// first.last, firstlast, flast or first.
+func emailMatchesName(email, name string) bool {
+	local := strings.ToLower(email[:strings.IndexByte(email, '@')])
+	local = strings.NewReplacer(".", "", "_", "", "-", "").Replace(local)
+
+	words := strings.Fields(strings.ToLower(name))
+	if len(words) < 2 {
+		return false
+	}
+	first, last := words[0], words[len(words)-1]
+	return local == first+last || local == first[:1]+last || local == first
+}
+
+// SuggestContacts extracts contacts from the job's description with
+// ExtractContacts and stores the new ones as suggestions for review. modelErr
+// reports a model failure, in which case only the regex results were stored;
+// err is a storage error.
+func SuggestContacts(ctx context.Context, st *store.Store, x ContactExtractor, job domain.Job) (added []domain.Contact, modelErr, err error) {
+	found, modelErr := ExtractContacts(ctx, x, job)
+	added, err = st.SuggestContacts(ctx, job.ID, found)
+	if err != nil {
+		return nil, modelErr, err
+	}
+	return added, modelErr, nil
+}
+
+// titleCase capitalizes each word of a role keyword ("hiring manager" →
+// "Hiring Manager").
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+	}
+	return strings.Join(words, " ")
+}
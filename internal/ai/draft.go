@@ -0,0 +1,117 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"jobflow.local/internal/domain"
+)
+
+// draftPromptVersion identifies draftPrompt in stored drafts, like
+// enrichPromptVersion.
+const draftPromptVersion = "draft-1"
+
+// DraftInput is what a draft is written from.
+type DraftInput struct {
+	Kind       string // domain.DraftCoverLetter or domain.DraftOutreach
+	Job        domain.Job
+	Enrichment *domain.Enrichment // nil if the job wasn't enriched
+	Profile    string             // the current resume
+	Contact    *domain.Contact    // the recipient of an outreach message
+	Tone       string             // optional, e.g. "warm", "formal"
+}
+
+// DraftResult is the generated text with its provenance.
+type DraftResult struct {
+	Content       string
+	Model         string
+	PromptVersion string
+}
+
+// Drafter writes cover letters and outreach messages.
+type Drafter interface {
+	Draft(ctx context.Context, in DraftInput) (DraftResult, error)
+}
+
+// Draft asks the model for a plain-text draft.
+func (e *ChatEnricher) Draft(ctx context.Context, in DraftInput) (DraftResult, error) {
+	prompt, err := draftPrompt(in)
+	if err != nil {
+		return DraftResult{}, err
+	}
+
+	content, err := e.complete(ctx, []chatMessage{{Role: "user", Content: prompt}}, nil)
+	if err != nil {
+		return DraftResult{}, err
+	}
+	content = stripCodeFence(content)
+	if content == "" {
+		return DraftResult{}, &InvalidOutputError{Reply: content, Problems: []string{"empty reply"}}
+	}
+	return DraftResult{
+		Content:       content,
+		Model:         e.model,
+		PromptVersion: draftPromptVersion,
+	}, nil
+}
+
+// draftPrompt is the single user message sent to the model.
+func draftPrompt(in DraftInput) (string, error) {
+	var task string
+	switch in.Kind {
+	case domain.DraftCoverLetter:
+		task = `Write a cover letter for this job, 250-400 words, addressed to the
+hiring team. Ground every claim in the candidate's profile; do not invent
+experience.`
+	case domain.DraftOutreach:
+		if in.Contact == nil {
+			return "", fmt.Errorf("outreach draft needs a contact")
+		}
+		task = fmt.Sprintf(`Write a short outreach message (at most 120 words) from the candidate to
+%s about this job, suitable for LinkedIn or email. Mention one or two
+relevant strengths from the profile and end with a light ask for a chat.`, describeContact(*in.Contact))
+	default:
+		return "", fmt.Errorf("unknown draft kind %q", in.Kind)
+	}
+
+	tone := in.Tone
+	if tone == "" {
+		tone = "professional and friendly"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `
+You are an AI assistant for job seekers.
+
+%s
+
+Tone: %s.
+Reply with the message text only: no subject line, no placeholders in
+brackets, no commentary.
+
+JOB TITLE: %s
+COMPANY: %s
+`, task, tone, in.Job.Title, in.Job.Company)
+	if in.Enrichment != nil {
+		fmt.Fprintf(&b, "SUMMARY: %s\nKEY SKILLS: %s\n", in.Enrichment.Summary, strings.Join(in.Enrichment.Skills, ", "))
+	}
+	fmt.Fprintf(&b, "\nDESCRIPTION:\n%s\n\nCANDIDATE PROFILE:\n%s\n", in.Job.Description, in.Profile)
+	return b.String(), nil
+}
+
+// describeContact names the recipient for the prompt, e.g. "Ana Lima
+// (Technical Recruiter)".
+func describeContact(c domain.Contact) string {
+	name := c.Name
+	if name == "" {
+		name = "a contact at the company"
+	}
+	if c.Role != "" {
+		name += " (" + c.Role + ")"
+	}
+	if c.Notes != "" {
+		name += ". Notes about them: " + c.Notes
+	}
+	return name
+}
@@ -0,0 +1,229 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Embedding providers, as AI_EMBEDDINGS names them.
+const (
+	EmbeddingsAPI   = "api"   // the /embeddings endpoint of the configured server
+	EmbeddingsLocal = "local" // HashEmbedder, offline
+	EmbeddingsOff   = "off"
+)
+
+// maxEmbedChars bounds the text sent for one embedding, well under the
+// input limit of common embedding models.
+const maxEmbedChars = 8000
+
+// Embedder turns text into a vector. Vectors are only comparable when they
+// come from the same Model.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+	Model() string
+}
+
+// NewEmbedder returns the embedder cfg.EmbeddingProvider selects, or nil
+// when it is EmbeddingsOff. When unset it is EmbeddingsAPI if an LLM is
+// configured and EmbeddingsLocal otherwise.
+func NewEmbedder(cfg Config) (Embedder, error) {
+	remote := cfg.BaseURL != "" || cfg.APIKey != ""
+	provider := cfg.EmbeddingProvider
+	if provider == "" {
+		provider = EmbeddingsLocal
+		if remote {
+			provider = EmbeddingsAPI
+		}
+	}
+
+	switch provider {
+	case EmbeddingsAPI:
+		if !remote {
+			return nil, fmt.Errorf("AI_EMBEDDINGS=%s needs AI_BASE_URL or an API key", EmbeddingsAPI)
+		}
+		return NewAPIEmbedder(cfg), nil
+	case EmbeddingsLocal:
+		return HashEmbedder{}, nil
+	case EmbeddingsOff:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("invalid AI_EMBEDDINGS %q (expected %s, %s or %s)", provider, EmbeddingsAPI, EmbeddingsLocal, EmbeddingsOff)
+}
+
+// APIEmbedder calls an OpenAI-style /embeddings endpoint: OpenAI itself, or
+// a compatible server such as Ollama.
+type APIEmbedder struct {
+	name    string // for error messages
+	baseURL string
+	model   string
+	apiKey  string
+	http    *http.Client
+}
+
+// NewAPIEmbedder talks to cfg.BaseURL (OpenAI when empty) with
+// cfg.EmbeddingModel. Empty settings fall back to the defaults.
+func NewAPIEmbedder(cfg Config) *APIEmbedder {
+	name := cfg.BaseURL
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultOpenAIBaseURL
+		name = "OpenAI"
+	}
+	if cfg.EmbeddingModel == "" {
+		cfg.EmbeddingModel = DefaultEmbeddingModel
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &APIEmbedder{
+		name:    name,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		model:   cfg.EmbeddingModel,
+		apiKey:  cfg.APIKey,
+		http:    &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (e *APIEmbedder) Model() string { return e.model }
+
+func (e *APIEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	bodyBytes, err := json.Marshal(map[string]any{
+		"model": e.model,
+		"input": truncate(text, maxEmbedChars),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal embeddings request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		e.baseURL+"/embeddings",
+		bytes.NewReader(bodyBytes),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create HTTP request: %w", err)
+	}
+	if e.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := e.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("call %s: %w", e.name, err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(httpResp.Body)
+		return nil, &StatusError{Provider: e.name, StatusCode: httpResp.StatusCode, Body: strings.TrimSpace(string(b))}
+	}
+
+	var resp struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decode embeddings response: %w", err)
+	}
+	if len(resp.Data) == 0 || len(resp.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("no embedding returned from %s", e.name)
+	}
+	return resp.Data[0].Embedding, nil
+}
+
+// hashDims is the size of HashEmbedder vectors.
+const hashDims = 512
+
+// HashEmbedder embeds text offline by hashing its words and word pairs into
+// a fixed-size vector. It knows nothing of synonyms, but the same posting
+// reworded a little still lands close, which is what duplicate detection
+// needs.
+type HashEmbedder struct{}
+
+func (HashEmbedder) Model() string { return fmt.Sprintf("local-hash-%d-v1", hashDims) }
+
+func (HashEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+
+	v := make([]float32, hashDims)
+	add := func(feature string) {
+		h := fnv.New32a()
+		h.Write([]byte(feature))
+		sum := h.Sum32()
+		// The top bit picks the sign so collisions cancel out on average.
+		if sum&(1<<31) != 0 {
+			v[sum%hashDims]--
+		} else {
+			v[sum%hashDims]++
+		}
+	}
+	prev := ""
+	for _, w := range words {
+		if len(w) < 2 {
+			continue
+		}
+		add(w)
+		if prev != "" {
+			add(prev + " " + w)
+		}
+		prev = w
+	}
+	return normalize(v), nil
+}
+
+// normalize scales v to unit length, leaving an all-zero vector as is.
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return v
+	}
+	n := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= n
+	}
+	return v
+}
+
+// cosine is the cosine similarity of a and b, 0 when their sizes differ or
+// either is all zeros.
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,299 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// fitPromptVersion identifies fitPrompt in stored scores, like
+// enrichPromptVersion.
+const fitPromptVersion = "fit-1"
+
+// maxSuggestions caps the suggestions list.
+const maxSuggestions = 5
+
+// FitResult is how well a resume fits a job, as rated by the LLM.
+type FitResult struct {
+	Score         int      `json:"score"` // 0-100
+	MatchedSkills []string `json:"matched_skills"`
+	MissingSkills []string `json:"missing_skills"`
+	Suggestions   []string `json:"suggestions"`
+
+	// Provenance, filled in by the FitScorer.
+	Model         string `json:"-"`
+	PromptVersion string `json:"-"`
+}
+
+// FitScorer rates a resume against a job. skills are the job's extracted
+// skills, if it was enriched.
+type FitScorer interface {
+	ScoreFit(ctx context.Context, resume string, job domain.Job, skills []string) (FitResult, error)
+}
+
+// fitResultSchema is the JSON schema of FitResult.
+var fitResultSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"score": map[string]any{"type": "integer", "minimum": 0, "maximum": 100},
+		"matched_skills": map[string]any{
+			"type":     "array",
+			"items":    map[string]any{"type": "string"},
+			"maxItems": maxSkills,
+		},
+		"missing_skills": map[string]any{
+			"type":     "array",
+			"items":    map[string]any{"type": "string"},
+			"maxItems": maxSkills,
+		},
+		"suggestions": map[string]any{
+			"type":     "array",
+			"items":    map[string]any{"type": "string"},
+			"maxItems": maxSuggestions,
+		},
+	},
+	"required":             []string{"score", "matched_skills", "missing_skills", "suggestions"},
+	"additionalProperties": false,
+}
+
+// ScoreFit asks the model to rate resume against job, with the same repair
+// turn as Enrich.
+func (e *ChatEnricher) ScoreFit(ctx context.Context, resume string, job domain.Job, skills []string) (FitResult, error) {
+	var fr FitResult
+	err := e.structured(ctx, fitPrompt(resume, job, skills), "fit_result", fitResultSchema,
+		func(content string) []string {
+			var problems []string
+			fr, problems = parseFitResult(content)
+			return problems
+		})
+	if err != nil {
+		return FitResult{}, err
+	}
+	fr.Model = e.model
+	fr.PromptVersion = fitPromptVersion
+	return fr, nil
+}
+
+// fitPrompt is the single user message sent to the model.
+func fitPrompt(resume string, job domain.Job, skills []string) string {
+	extracted := "(none)"
+	if len(skills) > 0 {
+		extracted = strings.Join(skills, ", ")
+	}
+	return fmt.Sprintf(`
+You are an AI assistant for job seekers.
+
+Rate how well the candidate's resume fits the job below.
+
+Return STRICT JSON only, with this exact shape:
+
+{
+  "score": 0-100, where 100 is a perfect fit,
+  "matched_skills": ["required skills the resume shows"],
+  "missing_skills": ["required skills the resume lacks"],
+  "suggestions": ["concrete change to the resume or application for this job"]
+}
+
+Skill lists have at most %d items and "suggestions" at most %d. Base the
+score on the job's requirements, not on the resume's length. Do NOT add any
+extra keys or text outside the JSON.
+
+JOB TITLE: %s
+COMPANY: %s
+EXTRACTED SKILLS: %s
+
+DESCRIPTION:
+%s
+
+RESUME:
+%s
+`, maxSkills, maxSuggestions, job.Title, job.Company, extracted, job.Description, resume)
+}
+
+// parseFitResult decodes a reply and checks it against fitResultSchema.
+func parseFitResult(content string) (FitResult, []string) {
+	var reply struct {
+		Score         *float64  `json:"score"`
+		MatchedSkills *[]string `json:"matched_skills"`
+		MissingSkills *[]string `json:"missing_skills"`
+		Suggestions   *[]string `json:"suggestions"`
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(stripCodeFence(content))))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reply); err != nil {
+		return FitResult{}, []string{"not a JSON object of the expected shape: " + err.Error()}
+	}
+	if dec.More() {
+		return FitResult{}, []string{"extra text after the JSON object"}
+	}
+
+	var problems []string
+	if reply.Score == nil {
+		problems = append(problems, `"score" is required`)
+	} else if v := *reply.Score; v != float64(int(v)) || v < 0 || v > 100 {
+		problems = append(problems, fmt.Sprintf(`"score" is %v, expected an integer from 0 to 100`, v))
+	}
+	for _, l := range []struct {
+		key   string
+		items *[]string
+		max   int
+	}{
+		{"matched_skills", reply.MatchedSkills, maxSkills},
+		{"missing_skills", reply.MissingSkills, maxSkills},
+		{"suggestions", reply.Suggestions, maxSuggestions},
+	} {
+		if l.items == nil {
+			problems = append(problems, fmt.Sprintf("%q is required", l.key))
+		} else if n := len(*l.items); n > l.max {
+			problems = append(problems, fmt.Sprintf("%q has %d items, at most %d allowed", l.key, n, l.max))
+		}
+	}
+	if len(problems) > 0 {
+		return FitResult{}, problems
+	}
+
+	return FitResult{
+		Score:         int(*reply.Score),
+		MatchedSkills: trimAll(*reply.MatchedSkills),
+		MissingSkills: trimAll(*reply.MissingSkills),
+		Suggestions:   trimAll(*reply.Suggestions),
+	}, nil
+}
+
+// trimAll trims every item and drops the empty ones.
+func trimAll(items []string) []string {
+	var out []string
+	for _, s := range items {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// fitBatch is how many unscored jobs a Fitter pass loads at a time.
+const fitBatch = 20
+
+// Fitter scores saved jobs against the current resume in the background,
+// and on demand for GET /jobs/{id}/fit.
+type Fitter struct {
+	scorer   FitScorer
+	store    *store.Store
+	interval time.Duration
+	kick     chan struct{}
+}
+
+func NewFitter(fs FitScorer, st *store.Store, interval time.Duration) *Fitter {
+	return &Fitter{
+		scorer:   fs,
+		store:    st,
+		interval: interval,
+		kick:     make(chan struct{}, 1),
+	}
+}
+
+// Kick starts a pass without waiting for the next tick, e.g. after a new
+// resume or job was saved.
+func (f *Fitter) Kick() {
+	select {
+	case f.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Run scores unscored jobs every interval, or when kicked, until ctx is done.
+func (f *Fitter) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.pass(ctx); err != nil {
+			log.Printf("[fit] %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-f.kick:
+		}
+	}
+}
+
+// pass scores every job that has no score against the current resume. It
+// stops at the first transient failure, so an outage is retried on the next
+// pass; permanent ones are stored as the job's score.
+func (f *Fitter) pass(ctx context.Context) error {
+	resume, err := f.store.GetCurrentResume(ctx)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load resume: %w", err)
+	}
+
+	for {
+		jobs, err := f.store.ListJobsWithoutFit(ctx, resume.ID, fitBatch)
+		if err != nil {
+			return fmt.Errorf("list unscored jobs: %w", err)
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+		for _, job := range jobs {
+			fs, err := f.Score(ctx, resume, job)
+			if err != nil {
+				return fmt.Errorf("score job %d: %w", job.ID, err)
+			}
+			if fs.Error != "" {
+				log.Printf("[fit] job %d: %s", job.ID, fs.Error)
+			}
+		}
+	}
+}
+
+// Score rates resume against job and stores the result. A permanent failure
+// (a reply that stays invalid after the repair turn, or a request the model
+// rejects, e.g. as too long) is stored with its error rather than returned,
+// so it isn't retried on every pass.
+func (f *Fitter) Score(ctx context.Context, resume domain.Resume, job domain.Job) (domain.FitScore, error) {
+	var skills []string
+	enr, err := f.store.GetLatestJobEnrichment(ctx, job.ID)
+	switch {
+	case err == nil:
+		skills = enr.Skills
+	case !errors.Is(err, store.ErrNotFound):
+		return domain.FitScore{}, err
+	}
+
+	fs := domain.FitScore{JobID: job.ID, ResumeID: resume.ID}
+	fr, err := f.scorer.ScoreFit(ctx, resume.Content, job, skills)
+	switch {
+	case err != nil && permanent(err):
+		fs.Error = err.Error()
+		fs.PromptVersion = fitPromptVersion
+	case err != nil:
+		return domain.FitScore{}, err
+	default:
+		fs.Score = fr.Score
+		fs.MatchedSkills = fr.MatchedSkills
+		fs.MissingSkills = fr.MissingSkills
+		fs.Suggestions = fr.Suggestions
+		fs.Model = fr.Model
+		fs.PromptVersion = fr.PromptVersion
+	}
+
+	if err := f.store.SaveFitScore(ctx, &fs); err != nil {
+		return domain.FitScore{}, err
+	}
+	return fs, nil
+}
@@ -0,0 +1,96 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"jobflow.local/internal/domain"
+)
+
+// scorerFunc adapts a function to FitScorer.
+type scorerFunc func(job domain.Job) (FitResult, error)
+
+func (f scorerFunc) ScoreFit(_ context.Context, _ string, job domain.Job, _ []string) (FitResult, error) {
+	return f(job)
+}
+
+func TestFitterPassStoresPermanentFailures(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	resume, err := st.SaveResume(ctx, "Go developer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tooLong := saveTestJob(t, st, domain.Job{ExternalID: "fit-1", Title: "Long", Description: "very long"})
+	ok := saveTestJob(t, st, domain.Job{ExternalID: "fit-2", Title: "Short", Description: "short"})
+
+	f := NewFitter(scorerFunc(func(job domain.Job) (FitResult, error) {
+		if job.ID == tooLong.ID {
+			return FitResult{}, &StatusError{Provider: "test", StatusCode: http.StatusBadRequest, Body: "context length exceeded"}
+		}
+		return FitResult{Score: 80}, nil
+	}), st, 0)
+
+	if err := f.pass(ctx); err != nil {
+		t.Fatalf("pass: %v", err)
+	}
+
+	failed, err := st.GetFitScore(ctx, tooLong.ID, resume.ID)
+	if err != nil {
+		t.Fatalf("failed job not stored: %v", err)
+	}
+	if failed.Error == "" {
+		t.Errorf("failed job stored without its error: %+v", failed)
+	}
+	scored, err := st.GetFitScore(ctx, ok.ID, resume.ID)
+	if err != nil {
+		t.Fatalf("later job not scored: %v", err)
+	}
+	if scored.Score != 80 {
+		t.Errorf("later job score = %d, want 80", scored.Score)
+	}
+}
+
+func TestFitterPassStopsOnTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	resume, err := st.SaveResume(ctx, "Go developer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := saveTestJob(t, st, domain.Job{ExternalID: "fit-3", Title: "Any", Description: "any"})
+
+	f := NewFitter(scorerFunc(func(domain.Job) (FitResult, error) {
+		return FitResult{}, &StatusError{Provider: "test", StatusCode: http.StatusServiceUnavailable}
+	}), st, 0)
+
+	if err := f.pass(ctx); err == nil {
+		t.Fatal("pass succeeded through an outage")
+	}
+	if _, err := st.GetFitScore(ctx, job.ID, resume.ID); err == nil {
+		t.Error("transient failure was stored as the job's score")
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&InvalidOutputError{Problems: []string{"bad"}}, true},
+		{&StatusError{StatusCode: http.StatusBadRequest}, true},
+		{&StatusError{StatusCode: http.StatusRequestEntityTooLarge}, true},
+		{&StatusError{StatusCode: http.StatusTooManyRequests}, false},
+		{&StatusError{StatusCode: http.StatusUnauthorized}, false},
+		{&StatusError{StatusCode: http.StatusBadGateway}, false},
+		{context.DeadlineExceeded, false},
+		{errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		if got := permanent(tt.err); got != tt.want {
+			t.Errorf("permanent(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Minimal types to talk to /chat/completions.
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string          `json:"model"`
+	Messages       []chatMessage   `json:"messages"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+}
+
+// responseFormat asks for structured output matching a JSON schema.
+type responseFormat struct {
+	Type       string     `json:"type"` // "json_schema"
+	JSONSchema jsonSchema `json:"json_schema"`
+}
+
+type jsonSchema struct {
+	Name   string         `json:"name"`
+	Strict bool           `json:"strict"`
+	Schema map[string]any `json:"schema"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// ChatEnricher enriches and scores jobs through an OpenAI-style chat completions
+// endpoint: OpenAI itself, or a compatible server such as Ollama or the
+// llama.cpp server.
+type ChatEnricher struct {
+	name    string // for error messages
+	baseURL string
+	model   string
+	apiKey  string
+	http    *http.Client
+}
+
+// NewOpenAI talks to OpenAI. Empty settings fall back to the defaults.
+func NewOpenAI(cfg Config) *ChatEnricher {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultOpenAIBaseURL
+	}
+	return newChatEnricher("OpenAI", cfg)
+}
+
+// NewOpenAICompatible talks to any server exposing /chat/completions under
+// cfg.BaseURL. The API key is only sent when set.
+func NewOpenAICompatible(cfg Config) *ChatEnricher {
+	return newChatEnricher(cfg.BaseURL, cfg)
+}
+
+func newChatEnricher(name string, cfg Config) *ChatEnricher {
+	if cfg.Model == "" {
+		cfg.Model = DefaultModel
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &ChatEnricher{
+		name:    name,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		model:   cfg.Model,
+		apiKey:  cfg.APIKey,
+		http:    &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Enrich asks the model for an EnrichedJob using structured output. A reply
+// that doesn't match the schema gets one repair turn; if that fails too the
+// error is an *InvalidOutputError.
+func (e *ChatEnricher) Enrich(ctx context.Context, rawText, role, company string) (EnrichedJob, error) {
+	var ej EnrichedJob
+	err := e.structured(ctx, enrichPrompt(rawText, role, company), "enriched_job", enrichedJobSchema,
+		func(content string) []string {
+			var problems []string
+			ej, problems = parseEnrichedJob(content)
+			return problems
+		})
+	if err != nil {
+		return EnrichedJob{}, err
+	}
+	return e.stamp(ej), nil
+}
+
+// structured sends prompt with schema as the response format and hands each
+// reply to parse, which returns what is wrong with it (nil when valid). An
+// invalid reply gets one repair turn, then an *InvalidOutputError.
+func (e *ChatEnricher) structured(ctx context.Context, prompt, name string, schema map[string]any, parse func(content string) []string) error {
+	format := &responseFormat{
+		Type: "json_schema",
+		JSONSchema: jsonSchema{
+			Name:   name,
+			Strict: true,
+			Schema: schema,
+		},
+	}
+	messages := []chatMessage{
+		{Role: "user", Content: prompt},
+	}
+
+	content, err := e.complete(ctx, messages, format)
+	if err != nil {
+		return err
+	}
+	problems := parse(content)
+	if problems == nil {
+		return nil
+	}
+
+	// Show the model its reply and what was wrong, and ask once more.
+	messages = append(messages,
+		chatMessage{Role: "assistant", Content: content},
+		chatMessage{Role: "user", Content: repairPrompt(problems, schema)},
+	)
+	content, err = e.complete(ctx, messages, format)
+	if err != nil {
+		return err
+	}
+	if problems := parse(content); problems != nil {
+		return &InvalidOutputError{Reply: content, Problems: problems}
+	}
+	return nil
+}
+
+// stamp records which model and prompt produced ej.
+func (e *ChatEnricher) stamp(ej EnrichedJob) EnrichedJob {
+	ej.Model = e.model
+	ej.PromptVersion = enrichPromptVersion
+	return ej
+}
+
+// StatusError is a non-200 reply from the model server.
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s HTTP %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// permanent reports whether err will happen again for the same input: an
+// unusable reply, or a request the server rejects as such (too long for the
+// model's context, malformed). Outages, rate limits and auth problems are
+// not, since they don't depend on the job.
+func permanent(err error) bool {
+	var invalid *InvalidOutputError
+	if errors.As(err, &invalid) {
+		return true
+	}
+	var status *StatusError
+	if errors.As(err, &status) {
+		switch status.StatusCode {
+		case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+			return true
+		}
+	}
+	return false
+}
+
+// complete sends the conversation and returns the reply text.
+func (e *ChatEnricher) complete(ctx context.Context, messages []chatMessage, format *responseFormat) (string, error) {
+	bodyBytes, err := json.Marshal(chatRequest{
+		Model:          e.model,
+		Messages:       messages,
+		ResponseFormat: format,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal chat request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		e.baseURL+"/chat/completions",
+		bytes.NewReader(bodyBytes),
+	)
+	if err != nil {
+		return "", fmt.Errorf("create HTTP request: %w", err)
+	}
+	if e.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := e.http.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("call %s: %w", e.name, err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(httpResp.Body)
+		return "", &StatusError{Provider: e.name, StatusCode: httpResp.StatusCode, Body: strings.TrimSpace(string(b))}
+	}
+
+	var chatResp chatResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&chatResp); err != nil {
+		return "", fmt.Errorf("decode chat response: %w", err)
+	}
+	if len(chatResp.Choices) == 0 {
+		return "", fmt.Errorf("no choices returned from %s", e.name)
+	}
+	return strings.TrimSpace(chatResp.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,188 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/salary"
+	"jobflow.local/internal/store"
+)
+
+// Queue retry policy: the wait doubles from queueBaseDelay, and a task is
+// marked failed after queueMaxAttempts. Permanent errors (replies that fail
+// validation even after the repair turn, requests the server rejects) are
+// not retried.
+const (
+	queueBaseDelay   = time.Minute
+	queueMaxAttempts = 3
+	queuePollEvery   = 10 * time.Second
+)
+
+// Queue runs the enrichment tasks stored in SQLite on a pool of workers.
+// The Notion side is handled by the outbox: completing a task queues the
+// page update. Contacts found in the description are suggested for review.
+type Queue struct {
+	enricher Enricher
+	store    *store.Store
+	workers  int
+	kick     chan struct{}
+}
+
+func NewQueue(e Enricher, st *store.Store, workers int) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Queue{
+		enricher: e,
+		store:    st,
+		workers:  workers,
+		kick:     make(chan struct{}, workers),
+	}
+}
+
+// Enqueue stores a task for the application and wakes a worker.
+func (q *Queue) Enqueue(ctx context.Context, appID int64) (store.EnrichmentTask, error) {
+	t, err := q.store.EnqueueEnrichment(ctx, appID)
+	if err != nil {
+		return t, err
+	}
+	q.Kick()
+	return t, nil
+}
+
+// Kick wakes an idle worker without waiting for the next poll.
+func (q *Queue) Kick() {
+	select {
+	case q.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Run starts the workers and blocks until ctx is done.
+func (q *Queue) Run(ctx context.Context) {
+	if err := q.store.ResetStaleEnrichments(ctx); err != nil {
+		log.Printf("[enrichment] reset stale tasks: %v", err)
+	}
+
+	done := make(chan struct{})
+	for range q.workers {
+		go func() {
+			q.work(ctx)
+			done <- struct{}{}
+		}()
+	}
+	for range q.workers {
+		<-done
+	}
+}
+
+// work claims and runs due tasks until none are left, then waits for a kick
+// or the next poll.
+func (q *Queue) work(ctx context.Context) {
+	ticker := time.NewTicker(queuePollEvery)
+	defer ticker.Stop()
+
+	for {
+		for {
+			t, ok, err := q.store.ClaimEnrichment(ctx)
+			if err != nil {
+				log.Printf("[enrichment] claim task: %v", err)
+				break
+			}
+			if !ok {
+				break
+			}
+			q.process(ctx, t)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.kick:
+		}
+	}
+}
+
+// process enriches one task and records the outcome.
+func (q *Queue) process(ctx context.Context, t store.EnrichmentTask) {
+	err := q.enrich(ctx, t)
+	if err == nil {
+		return
+	}
+
+	var retryAt *time.Time
+	if !permanent(err) && !errors.Is(err, store.ErrNotFound) && t.Attempts < queueMaxAttempts {
+		at := time.Now().Add(queueBaseDelay << (t.Attempts - 1))
+		retryAt = &at
+	}
+
+	if ferr := q.store.FailEnrichment(context.WithoutCancel(ctx), t.ID, err.Error(), retryAt); ferr != nil {
+		log.Printf("[enrichment] record failure of task %d: %v", t.ID, ferr)
+	}
+	if retryAt == nil {
+		log.Printf("[enrichment] task %d (application %d) failed after %d attempts: %v", t.ID, t.ApplicationID, t.Attempts, err)
+	} else {
+		log.Printf("[enrichment] task %d (application %d) failed, retry at %s: %v", t.ID, t.ApplicationID, retryAt.Format(time.RFC3339), err)
+	}
+}
+
+func (q *Queue) enrich(ctx context.Context, t store.EnrichmentTask) error {
+	app, err := q.store.GetApplication(ctx, t.ApplicationID)
+	if err != nil {
+		return err
+	}
+	job, err := q.store.GetJob(ctx, app.JobID)
+	if err != nil {
+		return err
+	}
+
+	ej, err := q.enricher.Enrich(ctx, job.Description, job.Title, job.Company)
+	if err != nil {
+		return err
+	}
+
+	// A salary read from the description fills in an empty salary field,
+	// before CompleteEnrichment so the Notion write carries it.
+	if job.Salary == "" && ej.Salary != "" {
+		if r, ok := salary.Parse(ej.Salary); ok {
+			applied, err := q.store.SaveInferredSalary(ctx, job.ID, r)
+			if err != nil {
+				return err
+			}
+			if applied {
+				log.Printf("[enrichment] job %d: inferred salary %q", job.ID, ej.Salary)
+			}
+		}
+	}
+
+	enr := domain.Enrichment{
+		JobID:         job.ID,
+		Summary:       ej.Summary,
+		Skills:        ej.Skills,
+		TailoredNote:  ej.TailoredNote,
+		Snippet:       ej.RawSnippet,
+		Model:         ej.Model,
+		PromptVersion: ej.PromptVersion,
+	}
+	if err := q.store.CompleteEnrichment(ctx, t.ID, app.ID, &enr); err != nil {
+		return err
+	}
+
+	// Contacts are a by-product: a failure here doesn't fail the task.
+	x, _ := q.enricher.(ContactExtractor)
+	added, modelErr, err := SuggestContacts(ctx, q.store, x, job)
+	if modelErr != nil {
+		log.Printf("[enrichment] contact extraction for job %d fell back to regex: %v", job.ID, modelErr)
+	}
+	if err != nil {
+		log.Printf("[enrichment] save suggested contacts for job %d: %v", job.ID, err)
+	}
+	if len(added) > 0 {
+		log.Printf("[enrichment] job %d: %d contact(s) suggested for review", job.ID, len(added))
+	}
+	return nil
+}
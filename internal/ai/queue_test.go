@@ -0,0 +1,63 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// enricherFunc adapts a function to Enricher.
+type enricherFunc func() (EnrichedJob, error)
+
+func (f enricherFunc) Enrich(context.Context, string, string, string) (EnrichedJob, error) {
+	return f()
+}
+
+func TestQueueRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus string
+	}{
+		{"rejected request", &StatusError{Provider: "test", StatusCode: http.StatusBadRequest, Body: "context length exceeded"}, store.EnrichmentFailed},
+		{"invalid reply", &InvalidOutputError{}, store.EnrichmentFailed},
+		{"server down", &StatusError{Provider: "test", StatusCode: http.StatusServiceUnavailable}, store.EnrichmentPending},
+		{"network error", errors.New("connection refused"), store.EnrichmentPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			st := newTestStore(t)
+			job := domain.Job{ExternalID: "q-1", Title: "Go Engineer", Company: "Acme", Description: "Go"}
+			app := domain.Application{Stage: "Applied"}
+			if err := st.UpsertJobAndApplication(ctx, &job, &app, nil, domain.SourceAPI); err != nil {
+				t.Fatal(err)
+			}
+
+			q := NewQueue(enricherFunc(func() (EnrichedJob, error) { return EnrichedJob{}, tt.err }), st, 1)
+			if _, err := st.EnqueueEnrichment(ctx, app.ID); err != nil {
+				t.Fatal(err)
+			}
+			task, ok, err := st.ClaimEnrichment(ctx)
+			if err != nil || !ok {
+				t.Fatalf("claim: ok %v, err %v", ok, err)
+			}
+			q.process(ctx, task)
+
+			got, err := st.GetLatestEnrichment(ctx, app.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("status after one attempt = %q, want %q", got.Status, tt.wantStatus)
+			}
+			if got.LastError == "" {
+				t.Error("error not stored")
+			}
+		})
+	}
+}
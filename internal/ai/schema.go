@@ -0,0 +1,110 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxSkills caps the skills list; longer lists are keyword dumps.
+const maxSkills = 15
+
+// enrichedJobSchema is the JSON schema of EnrichedJob, sent as the
+// response_format so servers that support structured outputs enforce it.
+var enrichedJobSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"summary": map[string]any{"type": "string"},
+		"skills": map[string]any{
+			"type":     "array",
+			"items":    map[string]any{"type": "string"},
+			"maxItems": maxSkills,
+		},
+		"tailored_note": map[string]any{"type": "string"},
+		"raw_snippet":   map[string]any{"type": "string"},
+		"salary":        map[string]any{"type": "string"},
+	},
+	"required":             []string{"summary", "skills", "tailored_note", "raw_snippet", "salary"},
+	"additionalProperties": false,
+}
+
+// InvalidOutputError is returned when the model's reply still doesn't match
+// the requested schema after the repair retry.
+type InvalidOutputError struct {
+	Reply    string   // the last reply, as received
+	Problems []string // what was wrong with it
+}
+
+func (e *InvalidOutputError) Error() string {
+	return "invalid model output: " + strings.Join(e.Problems, "; ")
+}
+
+// parseEnrichedJob decodes a reply and checks it against enrichedJobSchema.
+func parseEnrichedJob(content string) (EnrichedJob, []string) {
+	var reply struct {
+		Summary      *string   `json:"summary"`
+		Skills       *[]string `json:"skills"`
+		TailoredNote *string   `json:"tailored_note"`
+		RawSnippet   *string   `json:"raw_snippet"`
+		Salary       *string   `json:"salary"`
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(stripCodeFence(content))))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reply); err != nil {
+		return EnrichedJob{}, []string{"not a JSON object of the expected shape: " + err.Error()}
+	}
+	if dec.More() {
+		return EnrichedJob{}, []string{"extra text after the JSON object"}
+	}
+
+	var problems []string
+	if reply.Summary == nil || strings.TrimSpace(*reply.Summary) == "" {
+		problems = append(problems, `"summary" is required`)
+	}
+	if reply.Skills == nil {
+		problems = append(problems, `"skills" is required`)
+	} else if n := len(*reply.Skills); n > maxSkills {
+		problems = append(problems, fmt.Sprintf(`"skills" has %d items, at most %d allowed`, n, maxSkills))
+	}
+	if reply.TailoredNote == nil {
+		problems = append(problems, `"tailored_note" is required`)
+	}
+	if reply.RawSnippet == nil {
+		problems = append(problems, `"raw_snippet" is required`)
+	}
+	if reply.Salary == nil {
+		problems = append(problems, `"salary" is required`)
+	}
+	if len(problems) > 0 {
+		return EnrichedJob{}, problems
+	}
+
+	ej := EnrichedJob{
+		Summary:      strings.TrimSpace(*reply.Summary),
+		TailoredNote: strings.TrimSpace(*reply.TailoredNote),
+		RawSnippet:   strings.TrimSpace(*reply.RawSnippet),
+		Salary:       strings.TrimSpace(*reply.Salary),
+	}
+	for _, s := range *reply.Skills {
+		if s = strings.TrimSpace(s); s != "" {
+			ej.Skills = append(ej.Skills, s)
+		}
+	}
+	return ej, nil
+}
+
+// stripCodeFence removes a ```json … ``` wrapper, which models without
+// structured-output support often add.
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[i+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "```"))
+}
@@ -0,0 +1,210 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// embedBatch is how many jobs an Index pass loads at a time.
+const embedBatch = 50
+
+// Index retry policy: a job whose embedding fails is skipped for
+// embedRetryDelay, doubled after every further failure up to
+// embedMaxRetryDelay. Inputs the server rejects outright wait the maximum.
+const (
+	embedRetryDelay    = time.Minute
+	embedMaxRetryDelay = 24 * time.Hour
+)
+
+// DuplicateThreshold is the similarity from which two saved jobs are taken
+// to be the same posting, e.g. reposted under a new external_id.
+const DuplicateThreshold = 0.95
+
+// SimilarJob is a saved job ranked by its similarity to another, from -1 to
+// 1 (cosine of their embeddings).
+type SimilarJob struct {
+	Job        domain.Job
+	Similarity float64
+}
+
+// Index embeds saved jobs in the background and answers similarity queries
+// over the stored vectors of its embedder's model.
+type Index struct {
+	embedder Embedder
+	store    *store.Store
+	interval time.Duration
+	kick     chan struct{}
+}
+
+func NewIndex(e Embedder, st *store.Store, interval time.Duration) *Index {
+	return &Index{
+		embedder: e,
+		store:    st,
+		interval: interval,
+		kick:     make(chan struct{}, 1),
+	}
+}
+
+// Model is the embedding model the index compares under.
+func (ix *Index) Model() string {
+	return ix.embedder.Model()
+}
+
+// Kick starts a pass without waiting for the next tick.
+func (ix *Index) Kick() {
+	select {
+	case ix.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Run embeds jobs that have no vector every interval, or when kicked, until
+// ctx is done.
+func (ix *Index) Run(ctx context.Context) {
+	ticker := time.NewTicker(ix.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := ix.pass(ctx); err != nil {
+			log.Printf("[embeddings] %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-ix.kick:
+		}
+	}
+}
+
+// pass embeds every job with a description and no vector. A job that fails
+// is logged and recorded for a later retry, and the pass goes on with the
+// next one.
+func (ix *Index) pass(ctx context.Context) error {
+	for {
+		jobs, err := ix.store.ListJobsWithoutEmbedding(ctx, ix.Model(), embedBatch)
+		if err != nil {
+			return fmt.Errorf("list jobs without embedding: %w", err)
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+		for _, job := range jobs {
+			if _, err := ix.Embed(ctx, job); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.Printf("[embeddings] job %d: %v", job.ID, err)
+
+				retry := embedRetryDelay
+				if permanent(err) {
+					retry = embedMaxRetryDelay
+				}
+				if err := ix.store.FailJobEmbedding(ctx, job.ID, ix.Model(), err.Error(), retry, embedMaxRetryDelay); err != nil {
+					return fmt.Errorf("record embedding failure of job %d: %w", job.ID, err)
+				}
+			}
+		}
+	}
+}
+
+// Embed returns job's vector, computing and storing it when the job has
+// none yet or its text changed since.
+func (ix *Index) Embed(ctx context.Context, job domain.Job) ([]float32, error) {
+	text := embeddingText(job)
+	sum := sha256.Sum256([]byte(text))
+	hash := hex.EncodeToString(sum[:])
+
+	e, err := ix.store.GetJobEmbedding(ctx, job.ID, ix.Model())
+	switch {
+	case err == nil && e.ContentHash == hash:
+		return e.Vector, nil
+	case err != nil && !errors.Is(err, store.ErrNotFound):
+		return nil, err
+	}
+
+	vec, err := ix.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	err = ix.store.SaveJobEmbedding(ctx, store.JobEmbedding{
+		JobID:       job.ID,
+		Model:       ix.Model(),
+		Vector:      vec,
+		ContentHash: hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vec, nil
+}
+
+// Similar returns up to limit other saved jobs most similar to job, best
+// first, skipping those below minSimilarity. Jobs not embedded yet are left
+// out.
+func (ix *Index) Similar(ctx context.Context, job domain.Job, limit int, minSimilarity float64) ([]SimilarJob, error) {
+	vec, err := ix.Embed(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	all, err := ix.store.ListJobEmbeddings(ctx, ix.Model())
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		jobID int64
+		sim   float64
+	}
+	var ranked []scored
+	for _, e := range all {
+		if e.JobID == job.ID {
+			continue
+		}
+		if sim := cosine(vec, e.Vector); sim >= minSimilarity {
+			ranked = append(ranked, scored{e.JobID, sim})
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].sim != ranked[j].sim {
+			return ranked[i].sim > ranked[j].sim
+		}
+		return ranked[i].jobID < ranked[j].jobID
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	out := make([]SimilarJob, 0, len(ranked))
+	for _, r := range ranked {
+		j, err := ix.store.GetJob(ctx, r.jobID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue // deleted since
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, SimilarJob{Job: j, Similarity: r.sim})
+	}
+	return out, nil
+}
+
+// Duplicates returns the saved jobs that look like the same posting as job.
+func (ix *Index) Duplicates(ctx context.Context, job domain.Job) ([]SimilarJob, error) {
+	return ix.Similar(ctx, job, 5, DuplicateThreshold)
+}
+
+// embeddingText is what a job's vector is computed from.
+func embeddingText(job domain.Job) string {
+	return truncate(job.Title+"\n"+job.Company+"\n\n"+job.Description, maxEmbedChars)
+}
@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// embedderFunc adapts a function to Embedder under the model "test".
+type embedderFunc func(text string) ([]float32, error)
+
+func (f embedderFunc) Embed(_ context.Context, text string) ([]float32, error) { return f(text) }
+func (f embedderFunc) Model() string                                           { return "test" }
+
+func TestIndexPassContinuesPastFailures(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	tooLong := saveTestJob(t, st, domain.Job{ExternalID: "emb-1", Title: "Long", Description: "very long"})
+	down := saveTestJob(t, st, domain.Job{ExternalID: "emb-2", Title: "Flaky", Description: "flaky"})
+	ok := saveTestJob(t, st, domain.Job{ExternalID: "emb-3", Title: "Short", Description: "short"})
+
+	calls := map[string]int{}
+	ix := NewIndex(embedderFunc(func(text string) ([]float32, error) {
+		calls[text]++
+		switch text {
+		case embeddingText(tooLong):
+			return nil, &StatusError{Provider: "test", StatusCode: http.StatusBadRequest, Body: "context length exceeded"}
+		case embeddingText(down):
+			return nil, errors.New("connection refused")
+		}
+		return []float32{1, 0}, nil
+	}), st, 0)
+
+	if err := ix.pass(ctx); err != nil {
+		t.Fatalf("pass: %v", err)
+	}
+	if _, err := st.GetJobEmbedding(ctx, ok.ID, "test"); err != nil {
+		t.Errorf("job after the failures not embedded: %v", err)
+	}
+
+	// Failed jobs wait for their retry instead of being tried on every pass.
+	if err := ix.pass(ctx); err != nil {
+		t.Fatalf("second pass: %v", err)
+	}
+	for _, job := range []domain.Job{tooLong, down} {
+		if n := calls[embeddingText(job)]; n != 1 {
+			t.Errorf("job %d embedded %d times over two passes, want 1", job.ID, n)
+		}
+		if _, err := st.GetJobEmbedding(ctx, job.ID, "test"); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("job %d: err = %v, want ErrNotFound", job.ID, err)
+		}
+	}
+}
+
+func TestIndexDuplicates(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	desc := "We are hiring a senior Go engineer to build our payments platform with PostgreSQL and Kubernetes."
+	original := saveTestJob(t, st, domain.Job{ExternalID: "dup-1", Title: "Senior Go Engineer", Company: "Acme", Description: desc})
+	saveTestJob(t, st, domain.Job{ExternalID: "dup-2", Title: "Pastry Chef", Company: "Bakery", Description: "Bake bread and croissants every morning."})
+	repost := saveTestJob(t, st, domain.Job{ExternalID: "dup-3", Title: "Senior Go Engineer", Company: "Acme", Description: desc})
+
+	ix := NewIndex(HashEmbedder{}, st, 0)
+	if err := ix.pass(ctx); err != nil {
+		t.Fatalf("pass: %v", err)
+	}
+
+	dups, err := ix.Duplicates(ctx, repost)
+	if err != nil {
+		t.Fatalf("duplicates: %v", err)
+	}
+	if len(dups) != 1 || dups[0].Job.ID != original.ID {
+		t.Fatalf("duplicates = %+v, want job %d only", dups, original.ID)
+	}
+	if dups[0].Similarity < DuplicateThreshold {
+		t.Errorf("similarity = %v, want at least %v", dups[0].Similarity, DuplicateThreshold)
+	}
+
+	similar, err := ix.Similar(ctx, repost, 10, -1)
+	if err != nil {
+		t.Fatalf("similar: %v", err)
+	}
+	if len(similar) != 2 || similar[0].Job.ID != original.ID {
+		t.Errorf("similar = %+v, want the original first and the other job after", similar)
+	}
+}
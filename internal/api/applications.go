@@ -0,0 +1,364 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+type applicationResponse struct {
+	ID            int64       `json:"id"`
+	JobID         int64       `json:"job_id"`
+	Stage         string      `json:"stage"`
+	Outcome       string      `json:"outcome"`
+	Notes         string      `json:"notes"`
+	AppliedOn     *time.Time  `json:"applied_on,omitempty"`
+	InterviewTime *time.Time  `json:"interview_time,omitempty"`
+	NotionPageID  *string     `json:"notion_page_id,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	FitScore      *int        `json:"fit_score,omitempty"` // lists only
+	Job           *jobSummary `json:"job,omitempty"`
+}
+
+type jobSummary struct {
+	ID          int64                `json:"id"`
+	ExternalID  string               `json:"external_id,omitempty"`
+	Title       string               `json:"title"`
+	Company     string               `json:"company"`
+	Location    string               `json:"location"`
+	URL         string               `json:"url,omitempty"`
+	WorkMode    string               `json:"work_mode"`
+	Salary      string               `json:"salary,omitempty"`
+	SalaryRange *salaryRangeResponse `json:"salary_range,omitempty"`
+	Place       *placeResponse       `json:"place,omitempty"`
+}
+
+// placeResponse is a job's location as NormalizeLocation resolved it.
+type placeResponse struct {
+	City        string `json:"city,omitempty"`
+	Region      string `json:"region,omitempty"`
+	Country     string `json:"country,omitempty"`
+	RemoteScope string `json:"remote_scope,omitempty"`
+}
+
+func toPlaceResponse(p domain.Place) *placeResponse {
+	if p == (domain.Place{}) {
+		return nil
+	}
+	return &placeResponse{
+		City:        p.City,
+		Region:      p.Region,
+		Country:     p.Country,
+		RemoteScope: p.RemoteScope,
+	}
+}
+
+// salaryRangeResponse is a job's parsed salary; the annual_* fields are what
+// sort=salary and min_salary use.
+type salaryRangeResponse struct {
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Currency  string  `json:"currency,omitempty"`
+	Period    string  `json:"period"`
+	AnnualMin float64 `json:"annual_min"`
+	AnnualMax float64 `json:"annual_max"`
+	Inferred  bool    `json:"inferred"`
+}
+
+func toSalaryRangeResponse(r *domain.SalaryRange) *salaryRangeResponse {
+	if r == nil {
+		return nil
+	}
+	return &salaryRangeResponse{
+		Min:       r.Min,
+		Max:       r.Max,
+		Currency:  r.Currency,
+		Period:    r.Period,
+		AnnualMin: r.AnnualMin,
+		AnnualMax: r.AnnualMax,
+		Inferred:  r.Inferred,
+	}
+}
+
+func toApplicationResponse(app domain.Application) applicationResponse {
+	return applicationResponse{
+		ID:            app.ID,
+		JobID:         app.JobID,
+		Stage:         app.Stage,
+		Outcome:       app.Outcome,
+		Notes:         app.Notes,
+		AppliedOn:     app.AppliedOn,
+		InterviewTime: app.InterviewTime,
+		NotionPageID:  app.NotionPageID,
+		CreatedAt:     app.CreatedAt,
+	}
+}
+
+func toJobSummary(job domain.Job) *jobSummary {
+	return &jobSummary{
+		ID:          job.ID,
+		ExternalID:  job.ExternalID,
+		Title:       job.Title,
+		Company:     job.Company,
+		Location:    job.Location,
+		URL:         job.URL,
+		WorkMode:    job.WorkMode,
+		Salary:      job.Salary,
+		SalaryRange: toSalaryRangeResponse(job.SalaryRange),
+		Place:       toPlaceResponse(job.Place),
+	}
+}
+
+// parseDateParam accepts RFC3339 or a plain YYYY-MM-DD date. endOfDay moves
+// a plain date to the start of the next day, for exclusive upper bounds.
+func parseDateParam(v string, endOfDay bool) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// handleListApplications serves GET /applications.
+//
+// Query parameters:
+//
+//	stage, outcome, company, work_mode   exact match (company/work_mode ignore case)
+//	created_from, created_to             RFC3339 or YYYY-MM-DD; created_to is inclusive for dates
+//	has_notion                           true|false
+//	min_salary                           annual amount; jobs without a parsed salary are excluded
+//	sort                                 created_at|stage|outcome|company|work_mode|has_notion|fit|salary
+//	order                                asc|desc (default desc)
+//	limit                                1..200 (default 50)
+//	cursor                               next_cursor from the previous page
+func (s *Server) handleListApplications(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	f := store.ApplicationFilter{
+		Stage:    q.Get("stage"),
+		Outcome:  q.Get("outcome"),
+		Company:  q.Get("company"),
+		WorkMode: q.Get("work_mode"),
+		SortBy:   q.Get("sort"),
+		Desc:     true,
+		Limit:    50,
+		Cursor:   q.Get("cursor"),
+	}
+
+	if m := domain.NormalizeWorkMode(f.WorkMode); m != "" {
+		f.WorkMode = m // "onsite" finds "On-site"
+	}
+	if v := q.Get("created_from"); v != "" {
+		t, err := parseDateParam(v, false)
+		if err != nil {
+			http.Error(w, "invalid created_from (expected RFC3339 or YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		f.CreatedFrom = t
+	}
+	if v := q.Get("created_to"); v != "" {
+		t, err := parseDateParam(v, true)
+		if err != nil {
+			http.Error(w, "invalid created_to (expected RFC3339 or YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		f.CreatedTo = t
+	}
+	if v := q.Get("has_notion"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "invalid has_notion (expected true or false)", http.StatusBadRequest)
+			return
+		}
+		f.HasNotion = &b
+	}
+	if v := q.Get("min_salary"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid min_salary (expected a non-negative number)", http.StatusBadRequest)
+			return
+		}
+		f.MinSalary = &n
+	}
+	if f.SortBy != "" {
+		if _, ok := store.ApplicationSortFields[f.SortBy]; !ok {
+			http.Error(w, "invalid sort field", http.StatusBadRequest)
+			return
+		}
+	}
+	switch q.Get("order") {
+	case "", "desc":
+	case "asc":
+		f.Desc = false
+	default:
+		http.Error(w, "invalid order (expected asc or desc)", http.StatusBadRequest)
+		return
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 200 {
+			http.Error(w, "limit must be between 1 and 200", http.StatusBadRequest)
+			return
+		}
+		f.Limit = n
+	}
+
+	page, err := s.store.ListApplications(r.Context(), f)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeStoreError(w, r, "application", err)
+		return
+	}
+
+	out := make([]applicationResponse, 0, len(page.Items))
+	for _, it := range page.Items {
+		a := toApplicationResponse(it.Application)
+		a.Job = toJobSummary(it.Job)
+		a.FitScore = it.FitScore
+		out = append(out, a)
+	}
+
+	resp := map[string]any{
+		"count":        len(out),
+		"applications": out,
+	}
+	if page.NextCursor != "" {
+		resp["next_cursor"] = page.NextCursor
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type applicationEventResponse struct {
+	ID        int64     `json:"id"`
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// handleApplicationHistory returns the stage/outcome transitions of one
+// application, oldest first.
+func (s *Server) handleApplicationHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid application id", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.store.GetApplication(r.Context(), id); err != nil {
+		writeStoreError(w, r, "application", err)
+		return
+	}
+
+	events, err := s.store.ListApplicationEvents(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, r, "application", err)
+		return
+	}
+
+	out := make([]applicationEventResponse, 0, len(events))
+	for _, ev := range events {
+		out = append(out, applicationEventResponse{
+			ID:        ev.ID,
+			Field:     ev.Field,
+			OldValue:  ev.OldValue,
+			NewValue:  ev.NewValue,
+			Source:    ev.Source,
+			CreatedAt: ev.CreatedAt,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"application_id": id,
+		"events":         out,
+	})
+}
+
+// PATCH body: only the fields present are changed. An empty
+// next_interview clears it.
+type updateApplicationRequest struct {
+	Stage         *string `json:"stage"`
+	Outcome       *string `json:"outcome"`
+	Notes         *string `json:"notes"`
+	NextInterview *string `json:"next_interview"` // RFC3339, "" to clear
+}
+
+// handleUpdateApplication moves an application through the pipeline:
+// 1) Apply the partial update in SQLite (history and the Notion write are queued by the store)
+// 2) Push the new values to the Notion page now, if there is one (best effort)
+func (s *Server) handleUpdateApplication(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid application id", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	var req updateApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	patch := store.ApplicationPatch{
+		Stage:   req.Stage,
+		Outcome: req.Outcome,
+		Notes:   req.Notes,
+	}
+	if req.NextInterview != nil {
+		if *req.NextInterview == "" {
+			patch.ClearInterviewTime = true
+		} else {
+			t, err := time.Parse(time.RFC3339, *req.NextInterview)
+			if err != nil {
+				http.Error(w, "invalid next_interview datetime (expected RFC3339)", http.StatusBadRequest)
+				return
+			}
+			patch.InterviewTime = &t
+		}
+	}
+	if patch == (store.ApplicationPatch{}) {
+		http.Error(w, "nothing to update", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	app, err := s.store.UpdateApplication(ctx, id, patch, domain.SourceAPI)
+	if err != nil {
+		writeStoreError(w, r, "application", err)
+		return
+	}
+	log.Printf("[PATCH /applications/%d] DB update ok", id)
+
+	resp := map[string]any{
+		"ok":          true,
+		"application": toApplicationResponse(app),
+	}
+
+	// The update is already in the outbox; the worker delivers it.
+	if s.outbox != nil && app.NotionPageID != nil {
+		s.outbox.Kick()
+		resp["notion"] = "queued"
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"jobflow.local/internal/ai"
+	"jobflow.local/internal/domain"
+)
+
+type contactRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+	Role  *string `json:"role"`
+	Notes *string `json:"notes"`
+}
+
+type contactResponse struct {
+	ID         int64   `json:"id"`
+	JobID      int64   `json:"job_id"`
+	Name       string  `json:"name"`
+	Email      string  `json:"email"`
+	Role       string  `json:"role"`
+	Notes      string  `json:"notes"`
+	Source     string  `json:"source"`
+	Status     string  `json:"status"`
+	Confidence float64 `json:"confidence"`
+}
+
+func toContactResponse(c domain.Contact) contactResponse {
+	return contactResponse{
+		ID:         c.ID,
+		JobID:      c.JobID,
+		Name:       c.Name,
+		Email:      c.Email,
+		Role:       c.Role,
+		Notes:      c.Notes,
+		Source:     c.Source,
+		Status:     c.Status,
+		Confidence: c.Confidence,
+	}
+}
+
+// contactStatuses are the accepted values of ?status= on contact lists.
+var contactStatuses = []string{
+	domain.ContactStatusAccepted,
+	domain.ContactStatusSuggested,
+	domain.ContactStatusDiscarded,
+}
+
+// apply copies the fields present in the request onto c.
+func (req contactRequest) apply(c *domain.Contact) {
+	if req.Name != nil {
+		c.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Email != nil {
+		c.Email = strings.TrimSpace(*req.Email)
+	}
+	if req.Role != nil {
+		c.Role = strings.TrimSpace(*req.Role)
+	}
+	if req.Notes != nil {
+		c.Notes = *req.Notes
+	}
+}
+
+func (s *Server) handleCreateContact(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	var req contactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.store.GetJob(r.Context(), jobID); err != nil {
+		writeStoreError(w, r, "job", err)
+		return
+	}
+
+	c := domain.Contact{JobID: jobID}
+	req.apply(&c)
+	if c.Name == "" && c.Email == "" {
+		http.Error(w, "name or email is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.CreateContact(r.Context(), &c); err != nil {
+		writeStoreError(w, r, "contact", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toContactResponse(c))
+}
+
+// handleListContacts lists a job's contacts. Discarded suggestions are left
+// out unless asked for with ?status=discarded.
+func (s *Server) handleListContacts(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if status != "" && !slices.Contains(contactStatuses, status) {
+		http.Error(w, "invalid status (expected accepted, suggested or discarded)", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.store.GetJob(r.Context(), jobID); err != nil {
+		writeStoreError(w, r, "job", err)
+		return
+	}
+
+	contacts, err := s.store.ListContacts(r.Context(), jobID, status)
+	if err != nil {
+		writeStoreError(w, r, "contact", err)
+		return
+	}
+
+	out := make([]contactResponse, 0, len(contacts))
+	for _, c := range contacts {
+		out = append(out, toContactResponse(c))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"job_id":   jobID,
+		"contacts": out,
+	})
+}
+
+// loadJobContact resolves /jobs/{id}/contacts/{contactID}, answering 404 when
+// the contact does not exist or belongs to another job.
+func (s *Server) loadJobContact(w http.ResponseWriter, r *http.Request) (domain.Contact, bool) {
+	jobID, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return domain.Contact{}, false
+	}
+	contactID, ok := pathID(r, "contactID")
+	if !ok {
+		http.Error(w, "invalid contact id", http.StatusBadRequest)
+		return domain.Contact{}, false
+	}
+
+	c, err := s.store.GetContact(r.Context(), contactID)
+	if err != nil {
+		writeStoreError(w, r, "contact", err)
+		return domain.Contact{}, false
+	}
+	if c.JobID != jobID {
+		http.Error(w, "contact not found", http.StatusNotFound)
+		return domain.Contact{}, false
+	}
+	return c, true
+}
+
+func (s *Server) handleGetContact(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.loadJobContact(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, toContactResponse(c))
+}
+
+func (s *Server) handleUpdateContact(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req contactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	c, ok := s.loadJobContact(w, r)
+	if !ok {
+		return
+	}
+	req.apply(&c)
+	if c.Name == "" && c.Email == "" {
+		http.Error(w, "name or email is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.UpdateContact(r.Context(), c); err != nil {
+		writeStoreError(w, r, "contact", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toContactResponse(c))
+}
+
+func (s *Server) handleDeleteContact(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.loadJobContact(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.store.DeleteContact(r.Context(), c.ID); err != nil {
+		writeStoreError(w, r, "contact", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleExtractContacts looks for recruiters and contact emails in the job
+// description now (the enrichment queue also does it for new jobs) and
+// stores new ones as suggestions. Without an AI provider only the regex
+// fallback runs.
+func (s *Server) handleExtractContacts(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+
+	job, err := s.store.GetJob(r.Context(), jobID)
+	if err != nil {
+		writeStoreError(w, r, "job", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	resp := map[string]any{"job_id": jobID}
+	added, modelErr, err := ai.SuggestContacts(ctx, s.store, s.extractor, job)
+	if err != nil {
+		writeStoreError(w, r, "contact", err)
+		return
+	}
+	if modelErr != nil {
+		log.Printf("[/jobs/%d/contacts/extract] model extraction failed, used regex only: %v", jobID, modelErr)
+		resp["model_error"] = modelErr.Error()
+	}
+
+	out := make([]contactResponse, 0, len(added))
+	for _, c := range added {
+		out = append(out, toContactResponse(c))
+	}
+	resp["suggested"] = out
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type reviewContactRequest struct {
+	Decision string `json:"decision"` // "accept" or "discard"
+}
+
+// handleReviewContact accepts or discards a suggested contact. Discarded
+// contacts are kept so extraction doesn't suggest them again.
+func (s *Server) handleReviewContact(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req reviewContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	var status string
+	switch req.Decision {
+	case "accept":
+		status = domain.ContactStatusAccepted
+	case "discard":
+		status = domain.ContactStatusDiscarded
+	default:
+		http.Error(w, "invalid decision (expected accept or discard)", http.StatusBadRequest)
+		return
+	}
+
+	c, ok := s.loadJobContact(w, r)
+	if !ok {
+		return
+	}
+	if err := s.store.SetContactStatus(r.Context(), c.ID, status); err != nil {
+		writeStoreError(w, r, "contact", err)
+		return
+	}
+	c.Status = status
+	writeJSON(w, http.StatusOK, toContactResponse(c))
+}
+
+// handleContactReviewQueue lists the suggested contacts of every job, oldest
+// first, for review.
+func (s *Server) handleContactReviewQueue(w http.ResponseWriter, r *http.Request) {
+	contacts, err := s.store.ListContactsByStatus(r.Context(), domain.ContactStatusSuggested)
+	if err != nil {
+		writeStoreError(w, r, "contact", err)
+		return
+	}
+
+	out := make([]contactResponse, 0, len(contacts))
+	for _, c := range contacts {
+		out = append(out, toContactResponse(c))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count":    len(out),
+		"contacts": out,
+	})
+}
@@ -65,3 +65,33 @@ func (s *Server) handleDebugSearchDatabases(w http.ResponseWriter, r *http.Reque
 
 	_ = json.NewEncoder(w).Encode(out)
 }
+
+func (s *Server) handleDebugNotionSchema(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 8*time.Second)
+	defer cancel()
+
+	report, err := s.notion.CheckSchema(ctx)
+	w.Header().Set("Content-Type", "application/json")
+
+	if err != nil {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	issues := make([]string, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		issues = append(issues, issue.String())
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"ok":     report.OK(),
+		"issues": issues,
+	})
+}
+
+func (s *Server) handleDebugNotionStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.notion.Stats())
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobflow.local/internal/ai"
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// maxToneLength bounds the free-text tone of a draft request.
+const maxToneLength = 100
+
+type createDraftRequest struct {
+	Kind      string `json:"kind"`       // cover_letter (default) or outreach
+	ContactID *int64 `json:"contact_id"` // required for outreach
+	Tone      string `json:"tone"`       // optional, e.g. "warm", "formal"
+}
+
+type draftResponse struct {
+	ID            int64     `json:"id"`
+	ApplicationID int64     `json:"application_id"`
+	ContactID     *int64    `json:"contact_id,omitempty"`
+	Kind          string    `json:"kind"`
+	Version       int       `json:"version"`
+	Tone          string    `json:"tone,omitempty"`
+	Content       string    `json:"content"`
+	Model         string    `json:"model"`
+	PromptVersion string    `json:"prompt_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func toDraftResponse(d domain.Draft) draftResponse {
+	return draftResponse{
+		ID:            d.ID,
+		ApplicationID: d.ApplicationID,
+		ContactID:     d.ContactID,
+		Kind:          d.Kind,
+		Version:       d.Version,
+		Tone:          d.Tone,
+		Content:       d.Content,
+		Model:         d.Model,
+		PromptVersion: d.PromptVersion,
+		CreatedAt:     d.CreatedAt,
+	}
+}
+
+// handleCreateDraft generates a new version of a cover letter for the
+// application, or of an outreach message to one of its job's contacts, from
+// the job description and the current resume.
+func (s *Server) handleCreateDraft(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid application id", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	var req createDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	req.Tone = strings.TrimSpace(req.Tone)
+	if req.Kind == "" {
+		req.Kind = domain.DraftCoverLetter
+	}
+	switch {
+	case req.Kind != domain.DraftCoverLetter && req.Kind != domain.DraftOutreach:
+		http.Error(w, "invalid kind (expected cover_letter or outreach)", http.StatusBadRequest)
+		return
+	case req.Kind == domain.DraftOutreach && req.ContactID == nil:
+		http.Error(w, "contact_id is required for outreach", http.StatusBadRequest)
+		return
+	case req.Kind == domain.DraftCoverLetter && req.ContactID != nil:
+		http.Error(w, "contact_id only applies to outreach", http.StatusBadRequest)
+		return
+	case len(req.Tone) > maxToneLength:
+		http.Error(w, "tone is too long", http.StatusBadRequest)
+		return
+	}
+
+	app, err := s.store.GetApplication(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, r, "application", err)
+		return
+	}
+	job, err := s.store.GetJob(r.Context(), app.JobID)
+	if err != nil {
+		writeStoreError(w, r, "job", err)
+		return
+	}
+
+	in := ai.DraftInput{Kind: req.Kind, Job: job, Tone: req.Tone}
+	if req.ContactID != nil {
+		c, err := s.store.GetContact(r.Context(), *req.ContactID)
+		if err != nil {
+			writeStoreError(w, r, "contact", err)
+			return
+		}
+		if c.JobID != job.ID {
+			http.Error(w, "contact not found", http.StatusNotFound)
+			return
+		}
+		// Suggested contacts are unreviewed guesses, discarded ones were
+		// rejected; only write to people the user accepted.
+		if c.Status != domain.ContactStatusAccepted {
+			http.Error(w, "contact is "+c.Status+"; only accepted contacts get outreach", http.StatusConflict)
+			return
+		}
+		in.Contact = &c
+	}
+
+	resume, err := s.store.GetCurrentResume(r.Context())
+	if errors.Is(err, store.ErrNotFound) {
+		http.Error(w, "no resume uploaded yet (POST /profile/resume)", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		writeStoreError(w, r, "resume", err)
+		return
+	}
+	in.Profile = resume.Content
+
+	enr, err := s.store.GetLatestJobEnrichment(r.Context(), job.ID)
+	switch {
+	case err == nil:
+		in.Enrichment = &enr
+	case !errors.Is(err, store.ErrNotFound):
+		writeStoreError(w, r, "job", err)
+		return
+	}
+
+	if s.drafter == nil {
+		http.Error(w, "drafting is disabled (no AI provider configured)", http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	res, err := s.drafter.Draft(ctx, in)
+	if err != nil {
+		log.Printf("[/applications/%d/drafts] drafting failed: %v", id, err)
+		http.Error(w, "drafting failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	d := domain.Draft{
+		ApplicationID: app.ID,
+		ContactID:     req.ContactID,
+		Kind:          req.Kind,
+		Tone:          req.Tone,
+		Content:       res.Content,
+		Model:         res.Model,
+		PromptVersion: res.PromptVersion,
+	}
+	if err := s.store.SaveDraft(ctx, &d); err != nil {
+		writeStoreError(w, r, "draft", err)
+		return
+	}
+	log.Printf("[/applications/%d/drafts] saved %s v%d", id, d.Kind, d.Version)
+
+	writeJSON(w, http.StatusCreated, toDraftResponse(d))
+}
+
+// handleListDrafts returns every draft of an application, newest first.
+// Optional filters: kind, contact_id.
+func (s *Server) handleListDrafts(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid application id", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	f := store.DraftFilter{Kind: q.Get("kind")}
+	if v := q.Get("contact_id"); v != "" {
+		cid, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid contact_id", http.StatusBadRequest)
+			return
+		}
+		f.ContactID = &cid
+	}
+
+	if _, err := s.store.GetApplication(r.Context(), id); err != nil {
+		writeStoreError(w, r, "application", err)
+		return
+	}
+
+	drafts, err := s.store.ListDrafts(r.Context(), id, f)
+	if err != nil {
+		writeStoreError(w, r, "draft", err)
+		return
+	}
+
+	out := make([]draftResponse, 0, len(drafts))
+	for _, d := range drafts {
+		out = append(out, toDraftResponse(d))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"application_id": id,
+		"drafts":         out,
+	})
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+type jobEnrichmentResponse struct {
+	ID            int64     `json:"id"`
+	Summary       string    `json:"summary"`
+	Skills        []string  `json:"skills"`
+	TailoredNote  string    `json:"tailored_note"`
+	Snippet       string    `json:"snippet"`
+	Model         string    `json:"model"`
+	PromptVersion string    `json:"prompt_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type enrichmentResponse struct {
+	ApplicationID int64                  `json:"application_id"`
+	Status        string                 `json:"status"`
+	Attempts      int                    `json:"attempts"`
+	LastError     string                 `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time             `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+	Result        *jobEnrichmentResponse `json:"result,omitempty"`
+}
+
+func toJobEnrichmentResponse(enr domain.Enrichment) *jobEnrichmentResponse {
+	skills := enr.Skills
+	if skills == nil {
+		skills = []string{}
+	}
+	return &jobEnrichmentResponse{
+		ID:            enr.ID,
+		Summary:       enr.Summary,
+		Skills:        skills,
+		TailoredNote:  enr.TailoredNote,
+		Snippet:       enr.Snippet,
+		Model:         enr.Model,
+		PromptVersion: enr.PromptVersion,
+		CreatedAt:     enr.CreatedAt,
+	}
+}
+
+// handleApplicationEnrichment reports the latest enrichment task of an
+// application, with its result once done. Clients poll it after /apply; the
+// job's current enrichment is also part of GET /jobs/{id}.
+func (s *Server) handleApplicationEnrichment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid application id", http.StatusBadRequest)
+		return
+	}
+
+	t, err := s.store.GetLatestEnrichment(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, r, "enrichment", err)
+		return
+	}
+
+	resp := enrichmentResponse{
+		ApplicationID: t.ApplicationID,
+		Status:        t.Status,
+		Attempts:      t.Attempts,
+		LastError:     t.LastError,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+	if t.Status == store.EnrichmentPending {
+		resp.NextAttemptAt = &t.NextAttemptAt
+	}
+	if t.EnrichmentID != nil {
+		enr, err := s.store.GetJobEnrichment(r.Context(), *t.EnrichmentID)
+		if err != nil {
+			writeStoreError(w, r, "enrichment", err)
+			return
+		}
+		resp.Result = toJobEnrichmentResponse(enr)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// maxResumeBytes bounds POST /profile/resume bodies.
+const maxResumeBytes = 1 << 20
+
+// handleUploadResume stores a new current resume. The body is the resume
+// itself (plain text or markdown), or JSON {"content": "..."}. Jobs are then
+// rescored against it in the background.
+func (s *Server) handleUploadResume(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxResumeBytes))
+	if err != nil {
+		http.Error(w, "resume too large or unreadable", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	content := string(body)
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "application/json" {
+		var req struct {
+			Content string `json:"content"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			return
+		}
+		content = req.Content
+	}
+	content = strings.TrimSpace(content)
+	if content == "" {
+		http.Error(w, "resume is empty", http.StatusBadRequest)
+		return
+	}
+
+	resume, err := s.store.SaveResume(r.Context(), content)
+	if err != nil {
+		writeStoreError(w, r, "resume", err)
+		return
+	}
+	log.Printf("[/profile/resume] saved resume %d (%d bytes)", resume.ID, len(content))
+
+	scoring := "disabled"
+	if s.fit != nil {
+		s.fit.Kick()
+		scoring = "pending"
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"ok":         true,
+		"resume_id":  resume.ID,
+		"created_at": resume.CreatedAt,
+		"scoring":    scoring,
+	})
+}
+
+type fitResponse struct {
+	JobID         int64     `json:"job_id"`
+	ResumeID      int64     `json:"resume_id"`
+	Score         *int      `json:"score"` // null when Error is set
+	MatchedSkills []string  `json:"matched_skills"`
+	MissingSkills []string  `json:"missing_skills"`
+	Suggestions   []string  `json:"suggestions"`
+	Model         string    `json:"model,omitempty"`
+	PromptVersion string    `json:"prompt_version"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func toFitResponse(fs domain.FitScore) fitResponse {
+	resp := fitResponse{
+		JobID:         fs.JobID,
+		ResumeID:      fs.ResumeID,
+		MatchedSkills: fs.MatchedSkills,
+		MissingSkills: fs.MissingSkills,
+		Suggestions:   fs.Suggestions,
+		Model:         fs.Model,
+		PromptVersion: fs.PromptVersion,
+		Error:         fs.Error,
+		CreatedAt:     fs.CreatedAt,
+	}
+	if fs.Error == "" {
+		score := fs.Score
+		resp.Score = &score
+	}
+	for _, l := range []*[]string{&resp.MatchedSkills, &resp.MissingSkills, &resp.Suggestions} {
+		if *l == nil {
+			*l = []string{}
+		}
+	}
+	return resp
+}
+
+// handleJobFit returns the job's fit against the current resume. A job that
+// wasn't scored yet, or any job with ?refresh=true, is scored on the spot.
+func (s *Server) handleJobFit(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+	refresh := false
+	if v := r.URL.Query().Get("refresh"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "invalid refresh (expected true or false)", http.StatusBadRequest)
+			return
+		}
+		refresh = b
+	}
+
+	job, err := s.store.GetJob(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, r, "job", err)
+		return
+	}
+	resume, err := s.store.GetCurrentResume(r.Context())
+	if errors.Is(err, store.ErrNotFound) {
+		http.Error(w, "no resume uploaded yet (POST /profile/resume)", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeStoreError(w, r, "resume", err)
+		return
+	}
+
+	if !refresh {
+		fs, err := s.store.GetFitScore(r.Context(), job.ID, resume.ID)
+		if err == nil {
+			writeJSON(w, http.StatusOK, toFitResponse(fs))
+			return
+		}
+		if !errors.Is(err, store.ErrNotFound) || s.fit == nil {
+			writeStoreError(w, r, "fit score", err)
+			return
+		}
+	}
+
+	if s.fit == nil {
+		http.Error(w, "fit scoring is disabled (no AI provider configured)", http.StatusServiceUnavailable)
+		return
+	}
+	if job.Description == "" {
+		http.Error(w, "job has no description to score", http.StatusUnprocessableEntity)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	fs, err := s.fit.Score(ctx, resume, job)
+	if err != nil {
+		log.Printf("[/jobs/%d/fit] scoring failed: %v", id, err)
+		http.Error(w, "fit scoring failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	writeJSON(w, http.StatusOK, toFitResponse(fs))
+}
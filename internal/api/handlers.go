@@ -2,7 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
+
+	"jobflow.local/internal/store"
 )
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -12,3 +17,34 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 		"ok":     true,
 	})
 }
+
+// writeJSON encodes v as the JSON response body with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		log.Printf("encode response error: %v", err)
+	}
+}
+
+// pathID parses a positive integer path parameter such as {id}.
+func pathID(r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// writeStoreError answers 404 for store.ErrNotFound and 500 for anything
+// else, logging the latter with the request path.
+func writeStoreError(w http.ResponseWriter, r *http.Request, what string, err error) {
+	if errors.Is(err, store.ErrNotFound) {
+		http.Error(w, what+" not found", http.StatusNotFound)
+		return
+	}
+	log.Printf("[%s] DB error: %v", r.URL.Path, err)
+	http.Error(w, "db error: "+err.Error(), http.StatusInternalServerError)
+}
@@ -4,15 +4,20 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
-	"strings"
 	"time"
 
-	"jobflow.local/internal/ai"
 	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
 )
 
+// duplicateCheckTimeout bounds the near-duplicate check of /apply. With the
+// local embedder or an already stored vector it takes milliseconds; only a
+// remote embedding call can run into it.
+const duplicateCheckTimeout = 2 * time.Second
+
 // JSON payload we expect from the browser / requests.http.
 type applyRequest struct {
 	ExternalID    string  `json:"external_id"`
@@ -30,10 +35,10 @@ type applyRequest struct {
 }
 
 // handleApply is the main entry point for recording an application.
-// 1) Upsert Job + Application in SQLite
-// 2) Optionally call the LLM to enrich the notes
-// 3) Create a row in Notion (best effort)
-// 4) Save the Notion page id back into the DB (best effort)
+// 1) Upsert Job + Application in SQLite, queueing the Notion write with them
+// 2) Queue the AI enrichment; poll GET /applications/{id}/enrichment for it
+// 3) Wake the outbox up to deliver the Notion write in the background
+// 4) Warn about saved jobs that look like the same posting (best effort)
 func (s *Server) handleApply(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -49,6 +54,9 @@ func (s *Server) handleApply(w http.ResponseWriter, r *http.Request) {
 	}
 	log.Printf("[/apply] incoming payload: %+v", req)
 
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
 	// --- 1) Build job & application domain models --------------------------
 
 	job := domain.Job{
@@ -61,6 +69,7 @@ func (s *Server) handleApply(w http.ResponseWriter, r *http.Request) {
 		Salary:      req.Salary,
 		Description: req.Description,
 	}
+	domain.NormalizeJob(&job)
 
 	var interviewTime *time.Time
 	if req.NextInterview != nil && *req.NextInterview != "" {
@@ -80,77 +89,61 @@ func (s *Server) handleApply(w http.ResponseWriter, r *http.Request) {
 		InterviewTime: interviewTime,
 	}
 
-	// --- 2) AI enrichment (best effort) -----------------------------------
-
-	if req.Description != "" && req.Position != "" {
-		ej, err := ai.EnrichJobWithLLM(req.Description, req.Position, req.Company)
-		if err != nil {
-			log.Printf("[/apply] AI enrichment failed: %v", err)
-		} else {
-			var parts []string
-
-			if ej.Summary != "" {
-				parts = append(parts, "Summary:\n"+ej.Summary)
-			}
-			if len(ej.Skills) > 0 {
-				var bullets []string
-				for _, sk := range ej.Skills {
-					bullets = append(bullets, "- "+sk)
-				}
-				parts = append(parts, "Key skills:\n"+strings.Join(bullets, "\n"))
-			}
-			if ej.TailoredNote != "" {
-				parts = append(parts, "Note to self:\n"+ej.TailoredNote)
-			}
-			if ej.RawSnippet != "" {
-				parts = append(parts, "Description snippet:\n"+ej.RawSnippet)
-			}
-
-			if len(parts) > 0 {
-				if app.Notes != "" {
-					app.Notes += "\n\n"
-				}
-				app.Notes += "=== AI Summary & Talking Points ===\n" + strings.Join(parts, "\n\n")
-			}
-		}
-	}
+	// --- 2) Upsert in SQLite ----------------------------------------------
 
-	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
-	defer cancel()
-
-	// --- 3) Upsert in SQLite ----------------------------------------------
-
-	if err := s.store.UpsertJobAndApplication(ctx, &job, &app); err != nil {
+	if err := s.store.UpsertJobAndApplication(ctx, &job, &app, nil, domain.SourceAPI); err != nil {
 		log.Printf("[/apply] DB error in UpsertJobAndApplication: %v", err)
 		http.Error(w, "db error: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 	log.Printf("[/apply] DB upsert ok: job_id=%d application_id=%d", job.ID, app.ID)
 
-	// --- 4) Create row in Notion (best effort) -----------------------------
+	// --- 3) Queue AI enrichment (runs in the background) -------------------
 
-	var pageID string
-	if s.notion != nil {
-		pid, err := s.notion.CreateJobPage(ctx, job, app)
-		if err != nil {
-			log.Printf("[/apply] Notion error in CreateJobPage: %v", err)
+	enrichment := "disabled"
+	if s.enrichment != nil && req.Description != "" && req.Position != "" {
+		if _, err := s.enrichment.Enqueue(ctx, app.ID); err != nil {
+			log.Printf("[/apply] could not queue AI enrichment: %v", err)
+			enrichment = "error"
 		} else {
-			pageID = pid
-			log.Printf("[/apply] Notion page created: %s", pageID)
-
-			if err := s.store.SaveNotionPageID(ctx, app.ID, pageID); err != nil {
-				log.Printf("[/apply] warning: SaveNotionPageID failed: %v", err)
-			}
+			enrichment = store.EnrichmentPending
 		}
 	}
+	if s.fit != nil {
+		s.fit.Kick() // score the new job against the resume
+	}
+
+	// --- 4) Let the outbox deliver the queued Notion write -----------------
+	// Delivery goes through the retrying Notion transport, which can back off
+	// for a long time, so it never runs on the request.
 
 	resp := map[string]any{
 		"ok":             true,
 		"job_id":         job.ID,
 		"application_id": app.ID,
+		"enrichment":     enrichment,
 	}
-	if pageID != "" {
-		resp["notion_page_id"] = pageID
+	if s.outbox != nil {
+		s.outbox.Kick()
+		resp["notion"] = "queued"
+	}
+
+	// --- 5) Near-duplicate check (best effort) -------------------------------
+	// Embedding the new job here also indexes it. The check only gets
+	// duplicateCheckTimeout, so a slow embedding server can't hold up the
+	// apply: the warning is left out and the background pass embeds the job.
+
+	if s.index != nil && job.Description != "" {
+		dctx, dcancel := context.WithTimeout(r.Context(), duplicateCheckTimeout)
+		dups, err := s.index.Duplicates(dctx, job)
+		dcancel()
+		if err != nil {
+			log.Printf("[/apply] duplicate check skipped: %v", err)
+			s.index.Kick()
+		} else if len(dups) > 0 {
+			log.Printf("[/apply] job %d looks like %d saved job(s)", job.ID, len(dups))
+			resp["possible_duplicates"] = toSimilarJobResponses(dups)
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -159,3 +152,60 @@ func (s *Server) handleApply(w http.ResponseWriter, r *http.Request) {
 		log.Printf("[/apply] encode response error: %v", err)
 	}
 }
+
+type jobDetailResponse struct {
+	ID          int64                  `json:"id"`
+	ExternalID  string                 `json:"external_id,omitempty"`
+	Title       string                 `json:"title"`
+	Company     string                 `json:"company"`
+	Location    string                 `json:"location"`
+	URL         string                 `json:"url,omitempty"`
+	WorkMode    string                 `json:"work_mode"`
+	Salary      string                 `json:"salary,omitempty"`
+	SalaryRange *salaryRangeResponse   `json:"salary_range"` // null when not parsed or inferred
+	Place       *placeResponse         `json:"place,omitempty"`
+	Description string                 `json:"description"`
+	CreatedAt   time.Time              `json:"created_at"`
+	Enrichment  *jobEnrichmentResponse `json:"enrichment"` // null until enriched
+}
+
+// handleGetJob returns a job with its current AI enrichment.
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+
+	job, err := s.store.GetJob(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, r, "job", err)
+		return
+	}
+
+	resp := jobDetailResponse{
+		ID:          job.ID,
+		ExternalID:  job.ExternalID,
+		Title:       job.Title,
+		Company:     job.Company,
+		Location:    job.Location,
+		URL:         job.URL,
+		WorkMode:    job.WorkMode,
+		Salary:      job.Salary,
+		SalaryRange: toSalaryRangeResponse(job.SalaryRange),
+		Place:       toPlaceResponse(job.Place),
+		Description: job.Description,
+		CreatedAt:   job.CreatedAt,
+	}
+
+	enr, err := s.store.GetLatestJobEnrichment(r.Context(), id)
+	switch {
+	case err == nil:
+		resp.Enrichment = toJobEnrichmentResponse(enr)
+	case !errors.Is(err, store.ErrNotFound):
+		writeStoreError(w, r, "job", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"jobflow.local/internal/store"
+)
+
+type outboxItemResponse struct {
+	ID            int64     `json:"id"`
+	ApplicationID int64     `json:"application_id"`
+	Op            string    `json:"op"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func toOutboxItemResponse(it store.OutboxItem) outboxItemResponse {
+	return outboxItemResponse{
+		ID:            it.ID,
+		ApplicationID: it.ApplicationID,
+		Op:            it.Op,
+		Status:        it.Status,
+		Attempts:      it.Attempts,
+		LastError:     it.LastError,
+		NextAttemptAt: it.NextAttemptAt,
+		CreatedAt:     it.CreatedAt,
+		UpdatedAt:     it.UpdatedAt,
+	}
+}
+
+// handleListOutbox lists queued Notion writes. ?status= is "dead" (default)
+// or "pending".
+func (s *Server) handleListOutbox(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	status := q.Get("status")
+	switch status {
+	case "":
+		status = store.OutboxDead
+	case store.OutboxDead, store.OutboxPending:
+	default:
+		http.Error(w, `status must be "dead" or "pending"`, http.StatusBadRequest)
+		return
+	}
+
+	limit := 50
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 200 {
+			http.Error(w, "limit must be between 1 and 200", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	items, err := s.store.ListOutboxItems(r.Context(), status, limit)
+	if err != nil {
+		writeStoreError(w, r, "outbox item", err)
+		return
+	}
+
+	resp := make([]outboxItemResponse, 0, len(items))
+	for _, it := range items {
+		resp = append(resp, toOutboxItemResponse(it))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": resp})
+}
+
+// handleRetryOutboxItem puts a dead-lettered write back in the queue and
+// wakes the outbox worker.
+func (s *Server) handleRetryOutboxItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid outbox item id", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.RetryOutboxItem(r.Context(), id); err != nil {
+		writeStoreError(w, r, "dead outbox item", err)
+		return
+	}
+	if s.outbox != nil {
+		s.outbox.Kick()
+	}
+	writeJSON(w, http.StatusAccepted, map[string]any{"id": id, "status": store.OutboxPending})
+}
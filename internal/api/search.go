@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type jobSearchHitResponse struct {
+	JobID            int64   `json:"job_id"`
+	ExternalID       string  `json:"external_id,omitempty"`
+	Title            string  `json:"title"`
+	Company          string  `json:"company"`
+	Location         string  `json:"location"`
+	URL              string  `json:"url,omitempty"`
+	WorkMode         string  `json:"work_mode,omitempty"`
+	TitleHighlight   string  `json:"title_highlight"`
+	CompanyHighlight string  `json:"company_highlight"`
+	Snippet          string  `json:"snippet"`
+	Rank             float64 `json:"rank"`
+}
+
+// handleSearchJobs serves GET /jobs/search?q=…&limit=…
+func (s *Server) handleSearchJobs(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		http.Error(w, "missing q", http.StatusBadRequest)
+		return
+	}
+
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 100 {
+			http.Error(w, "limit must be between 1 and 100", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	hits, err := s.store.SearchJobs(r.Context(), q, limit)
+	if err != nil {
+		writeStoreError(w, r, "job", err)
+		return
+	}
+
+	out := make([]jobSearchHitResponse, 0, len(hits))
+	for _, h := range hits {
+		out = append(out, jobSearchHitResponse{
+			JobID:            h.Job.ID,
+			ExternalID:       h.Job.ExternalID,
+			Title:            h.Job.Title,
+			Company:          h.Job.Company,
+			Location:         h.Job.Location,
+			URL:              h.Job.URL,
+			WorkMode:         h.Job.WorkMode,
+			TitleHighlight:   h.TitleHighlight,
+			CompanyHighlight: h.CompanyHighlight,
+			Snippet:          h.Snippet,
+			Rank:             h.Rank,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"query":   q,
+		"count":   len(out),
+		"results": out,
+	})
+}
@@ -4,21 +4,34 @@ import (
 	"log"
 	"net/http"
 
+	"jobflow.local/internal/ai"
 	"jobflow.local/internal/notion"
 	"jobflow.local/internal/store"
 )
 
 type Server struct {
-	store  *store.Store
-	notion *notion.Client
-	mux    *http.ServeMux
+	store      *store.Store
+	notion     *notion.Client
+	outbox     *notion.Outbox
+	enrichment *ai.Queue           // nil when enrichment is off
+	fit        *ai.Fitter          // nil when enrichment is off
+	drafter    ai.Drafter          // nil when enrichment is off
+	extractor  ai.ContactExtractor // nil when enrichment is off; the regex fallback still runs
+	index      *ai.Index           // nil when embeddings are off
+	mux        *http.ServeMux
 }
 
-func New(st *store.Store, n *notion.Client) *Server {
+func New(st *store.Store, n *notion.Client, ob *notion.Outbox, eq *ai.Queue, fit *ai.Fitter, d ai.Drafter, x ai.ContactExtractor, ix *ai.Index) *Server {
 	s := &Server{
-		store:  st,
-		notion: n,
-		mux:    http.NewServeMux(),
+		store:      st,
+		notion:     n,
+		outbox:     ob,
+		enrichment: eq,
+		fit:        fit,
+		drafter:    d,
+		extractor:  x,
+		index:      ix,
+		mux:        http.NewServeMux(),
 	}
 	s.routes()
 	return s
@@ -28,10 +41,38 @@ func (s *Server) routes() {
 	s.mux.HandleFunc("GET /health", s.handleHealth)
 	s.mux.HandleFunc("GET /debug/notion", s.handleDebugNotion)
 	s.mux.HandleFunc("GET /debug/notion/search", s.handleDebugSearchDatabases)
+	s.mux.HandleFunc("GET /debug/notion/schema", s.handleDebugNotionSchema)
+	s.mux.HandleFunc("GET /debug/notion/stats", s.handleDebugNotionStats)
 
 	// CORS preflight + main handler
 	s.mux.HandleFunc("OPTIONS /apply", s.handleApply) // same func handles OPTIONS shortcut
 	s.mux.HandleFunc("POST /apply", s.handleApply)
+
+	s.mux.HandleFunc("GET /applications", s.handleListApplications)
+	s.mux.HandleFunc("PATCH /applications/{id}", s.handleUpdateApplication)
+	s.mux.HandleFunc("GET /applications/{id}/history", s.handleApplicationHistory)
+	s.mux.HandleFunc("GET /applications/{id}/enrichment", s.handleApplicationEnrichment)
+	s.mux.HandleFunc("POST /applications/{id}/drafts", s.handleCreateDraft)
+	s.mux.HandleFunc("GET /applications/{id}/drafts", s.handleListDrafts)
+
+	s.mux.HandleFunc("GET /jobs/search", s.handleSearchJobs)
+	s.mux.HandleFunc("GET /jobs/{id}", s.handleGetJob)
+	s.mux.HandleFunc("GET /jobs/{id}/fit", s.handleJobFit)
+	s.mux.HandleFunc("GET /jobs/{id}/similar", s.handleSimilarJobs)
+
+	s.mux.HandleFunc("POST /profile/resume", s.handleUploadResume)
+
+	s.mux.HandleFunc("POST /jobs/{id}/contacts", s.handleCreateContact)
+	s.mux.HandleFunc("GET /jobs/{id}/contacts", s.handleListContacts)
+	s.mux.HandleFunc("GET /jobs/{id}/contacts/{contactID}", s.handleGetContact)
+	s.mux.HandleFunc("PATCH /jobs/{id}/contacts/{contactID}", s.handleUpdateContact)
+	s.mux.HandleFunc("DELETE /jobs/{id}/contacts/{contactID}", s.handleDeleteContact)
+	s.mux.HandleFunc("POST /jobs/{id}/contacts/extract", s.handleExtractContacts)
+	s.mux.HandleFunc("POST /jobs/{id}/contacts/{contactID}/review", s.handleReviewContact)
+	s.mux.HandleFunc("GET /contacts/review", s.handleContactReviewQueue)
+
+	s.mux.HandleFunc("GET /notion/outbox", s.handleListOutbox)
+	s.mux.HandleFunc("POST /notion/outbox/{id}/retry", s.handleRetryOutboxItem)
 }
 
 // Helper used by handlers to allow browser extension → API calls.
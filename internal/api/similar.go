@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"jobflow.local/internal/ai"
+)
+
+type similarJobResponse struct {
+	JobID      int64   `json:"job_id"`
+	Title      string  `json:"title"`
+	Company    string  `json:"company"`
+	Location   string  `json:"location,omitempty"`
+	URL        string  `json:"url,omitempty"`
+	Similarity float64 `json:"similarity"`
+	Duplicate  bool    `json:"likely_duplicate"` // at or above ai.DuplicateThreshold
+}
+
+func toSimilarJobResponses(jobs []ai.SimilarJob) []similarJobResponse {
+	out := make([]similarJobResponse, 0, len(jobs))
+	for _, sj := range jobs {
+		out = append(out, similarJobResponse{
+			JobID:      sj.Job.ID,
+			Title:      sj.Job.Title,
+			Company:    sj.Job.Company,
+			Location:   sj.Job.Location,
+			URL:        sj.Job.URL,
+			Similarity: sj.Similarity,
+			Duplicate:  sj.Similarity >= ai.DuplicateThreshold,
+		})
+	}
+	return out
+}
+
+// handleSimilarJobs returns the saved jobs whose description is closest to
+// this one's, best first. Optional: limit (1..50, default 10).
+func (s *Server) handleSimilarJobs(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+	limit := 10
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 50 {
+			http.Error(w, "limit must be between 1 and 50", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	job, err := s.store.GetJob(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, r, "job", err)
+		return
+	}
+	if s.index == nil {
+		http.Error(w, "similar jobs are disabled (AI_EMBEDDINGS=off)", http.StatusServiceUnavailable)
+		return
+	}
+	if job.Description == "" {
+		http.Error(w, "job has no description to compare", http.StatusUnprocessableEntity)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	similar, err := s.index.Similar(ctx, job, limit, -1)
+	if err != nil {
+		log.Printf("[/jobs/%d/similar] %v", id, err)
+		http.Error(w, "similarity search failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"job_id":  id,
+		"model":   s.index.Model(),
+		"similar": toSimilarJobResponses(similar),
+	})
+}
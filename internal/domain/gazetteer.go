@@ -0,0 +1,288 @@
+package domain
+
+// The bundled gazetteer NormalizeLocation resolves places against: countries,
+// their first-level regions where job boards commonly name them, and the
+// cities postings most often list. It is deliberately small and offline;
+// anything it doesn't know is kept as written.
+
+type gazCountry struct {
+	code    string // ISO 3166-1 alpha-2
+	name    string
+	aliases []string
+}
+
+type gazRegion struct {
+	country string
+	code    string // as job boards abbreviate it ("CA", "ON"); "" if none
+	name    string
+}
+
+type gazCity struct {
+	name    string
+	region  string // gazRegion name, "" if not tracked
+	country string
+	aliases []string
+}
+
+var countries = []gazCountry{
+	{"US", "United States", []string{"USA", "U.S.", "U.S.A.", "United States of America", "America"}},
+	{"CA", "Canada", nil},
+	{"MX", "Mexico", []string{"México"}},
+	{"BR", "Brazil", []string{"Brasil"}},
+	{"AR", "Argentina", nil},
+	{"CL", "Chile", nil},
+	{"CO", "Colombia", nil},
+	{"PE", "Peru", []string{"Perú"}},
+	{"UY", "Uruguay", nil},
+	{"CR", "Costa Rica", nil},
+	{"GB", "United Kingdom", []string{"UK", "U.K.", "Great Britain", "Britain"}},
+	{"IE", "Ireland", nil},
+	{"FR", "France", nil},
+	{"DE", "Germany", []string{"Deutschland"}},
+	{"NL", "Netherlands", []string{"The Netherlands", "Holland"}},
+	{"BE", "Belgium", nil},
+	{"LU", "Luxembourg", nil},
+	{"CH", "Switzerland", nil},
+	{"AT", "Austria", nil},
+	{"ES", "Spain", []string{"España"}},
+	{"PT", "Portugal", nil},
+	{"IT", "Italy", []string{"Italia"}},
+	{"SE", "Sweden", nil},
+	{"NO", "Norway", nil},
+	{"DK", "Denmark", nil},
+	{"FI", "Finland", nil},
+	{"IS", "Iceland", nil},
+	{"PL", "Poland", []string{"Polska"}},
+	{"CZ", "Czechia", []string{"Czech Republic"}},
+	{"SK", "Slovakia", nil},
+	{"HU", "Hungary", nil},
+	{"RO", "Romania", nil},
+	{"BG", "Bulgaria", nil},
+	{"GR", "Greece", nil},
+	{"HR", "Croatia", nil},
+	{"SI", "Slovenia", nil},
+	{"RS", "Serbia", nil},
+	{"EE", "Estonia", nil},
+	{"LV", "Latvia", nil},
+	{"LT", "Lithuania", nil},
+	{"UA", "Ukraine", nil},
+	{"TR", "Turkey", []string{"Türkiye", "Turkiye"}},
+	{"IL", "Israel", nil},
+	{"AE", "United Arab Emirates", []string{"UAE", "U.A.E."}},
+	{"SA", "Saudi Arabia", nil},
+	{"QA", "Qatar", nil},
+	{"EG", "Egypt", nil},
+	{"MA", "Morocco", nil},
+	{"NG", "Nigeria", nil},
+	{"KE", "Kenya", nil},
+	{"ZA", "South Africa", nil},
+	{"IN", "India", nil},
+	{"PK", "Pakistan", nil},
+	{"BD", "Bangladesh", nil},
+	{"LK", "Sri Lanka", nil},
+	{"CN", "China", nil},
+	{"HK", "Hong Kong", []string{"Hong Kong SAR"}},
+	{"TW", "Taiwan", nil},
+	{"JP", "Japan", nil},
+	{"KR", "South Korea", []string{"Korea", "Republic of Korea"}},
+	{"SG", "Singapore", nil},
+	{"MY", "Malaysia", nil},
+	{"TH", "Thailand", nil},
+	{"VN", "Vietnam", []string{"Viet Nam"}},
+	{"PH", "Philippines", nil},
+	{"ID", "Indonesia", nil},
+	{"AU", "Australia", nil},
+	{"NZ", "New Zealand", nil},
+}
+
+var regions = []gazRegion{
+	// United States
+	{"US", "AL", "Alabama"}, {"US", "AK", "Alaska"}, {"US", "AZ", "Arizona"}, {"US", "AR", "Arkansas"},
+	{"US", "CA", "California"}, {"US", "CO", "Colorado"}, {"US", "CT", "Connecticut"}, {"US", "DE", "Delaware"},
+	{"US", "DC", "District of Columbia"}, {"US", "FL", "Florida"}, {"US", "GA", "Georgia"}, {"US", "HI", "Hawaii"},
+	{"US", "ID", "Idaho"}, {"US", "IL", "Illinois"}, {"US", "IN", "Indiana"}, {"US", "IA", "Iowa"},
+	{"US", "KS", "Kansas"}, {"US", "KY", "Kentucky"}, {"US", "LA", "Louisiana"}, {"US", "ME", "Maine"},
+	{"US", "MD", "Maryland"}, {"US", "MA", "Massachusetts"}, {"US", "MI", "Michigan"}, {"US", "MN", "Minnesota"},
+	{"US", "MS", "Mississippi"}, {"US", "MO", "Missouri"}, {"US", "MT", "Montana"}, {"US", "NE", "Nebraska"},
+	{"US", "NV", "Nevada"}, {"US", "NH", "New Hampshire"}, {"US", "NJ", "New Jersey"}, {"US", "NM", "New Mexico"},
+	{"US", "NY", "New York"}, {"US", "NC", "North Carolina"}, {"US", "ND", "North Dakota"}, {"US", "OH", "Ohio"},
+	{"US", "OK", "Oklahoma"}, {"US", "OR", "Oregon"}, {"US", "PA", "Pennsylvania"}, {"US", "RI", "Rhode Island"},
+	{"US", "SC", "South Carolina"}, {"US", "SD", "South Dakota"}, {"US", "TN", "Tennessee"}, {"US", "TX", "Texas"},
+	{"US", "UT", "Utah"}, {"US", "VT", "Vermont"}, {"US", "VA", "Virginia"}, {"US", "WA", "Washington"},
+	{"US", "WV", "West Virginia"}, {"US", "WI", "Wisconsin"}, {"US", "WY", "Wyoming"}, {"US", "PR", "Puerto Rico"},
+
+	// Canada
+	{"CA", "AB", "Alberta"}, {"CA", "BC", "British Columbia"}, {"CA", "MB", "Manitoba"}, {"CA", "NB", "New Brunswick"},
+	{"CA", "NL", "Newfoundland and Labrador"}, {"CA", "NS", "Nova Scotia"}, {"CA", "ON", "Ontario"},
+	{"CA", "PE", "Prince Edward Island"}, {"CA", "QC", "Quebec"}, {"CA", "SK", "Saskatchewan"},
+
+	// Australia
+	{"AU", "NSW", "New South Wales"}, {"AU", "VIC", "Victoria"}, {"AU", "QLD", "Queensland"},
+	{"AU", "WA", "Western Australia"}, {"AU", "SA", "South Australia"}, {"AU", "TAS", "Tasmania"},
+	{"AU", "ACT", "Australian Capital Territory"}, {"AU", "NT", "Northern Territory"},
+
+	// United Kingdom
+	{"GB", "", "England"}, {"GB", "", "Scotland"}, {"GB", "", "Wales"}, {"GB", "", "Northern Ireland"},
+
+	// Germany
+	{"DE", "", "Baden-Württemberg"}, {"DE", "", "Bavaria"}, {"DE", "", "Berlin"}, {"DE", "", "Brandenburg"},
+	{"DE", "", "Hamburg"}, {"DE", "", "Hesse"}, {"DE", "", "Lower Saxony"}, {"DE", "", "North Rhine-Westphalia"},
+	{"DE", "", "Saxony"},
+
+	// India
+	{"IN", "", "Karnataka"}, {"IN", "", "Maharashtra"}, {"IN", "", "Telangana"}, {"IN", "", "Tamil Nadu"},
+	{"IN", "", "Delhi"}, {"IN", "", "Haryana"}, {"IN", "", "Uttar Pradesh"}, {"IN", "", "West Bengal"},
+
+	// Brazil
+	{"BR", "SP", "São Paulo"}, {"BR", "RJ", "Rio de Janeiro"}, {"BR", "MG", "Minas Gerais"},
+}
+
+var cities = []gazCity{
+	// United States
+	{"New York", "New York", "US", []string{"New York City", "NYC", "Manhattan", "Brooklyn"}},
+	{"San Francisco", "California", "US", []string{"SF"}},
+	{"Los Angeles", "California", "US", []string{"LA"}},
+	{"San Jose", "California", "US", nil},
+	{"San Diego", "California", "US", nil},
+	{"Palo Alto", "California", "US", nil},
+	{"Mountain View", "California", "US", nil},
+	{"Sunnyvale", "California", "US", nil},
+	{"Menlo Park", "California", "US", nil},
+	{"Oakland", "California", "US", nil},
+	{"Seattle", "Washington", "US", nil},
+	{"Redmond", "Washington", "US", nil},
+	{"Bellevue", "Washington", "US", nil},
+	{"Boston", "Massachusetts", "US", nil},
+	{"Cambridge", "Massachusetts", "US", nil},
+	{"Austin", "Texas", "US", nil},
+	{"Dallas", "Texas", "US", []string{"Dallas-Fort Worth", "DFW"}},
+	{"Houston", "Texas", "US", nil},
+	{"Chicago", "Illinois", "US", nil},
+	{"Denver", "Colorado", "US", nil},
+	{"Boulder", "Colorado", "US", nil},
+	{"Atlanta", "Georgia", "US", nil},
+	{"Miami", "Florida", "US", nil},
+	{"Washington", "District of Columbia", "US", []string{"Washington DC", "Washington D.C.", "DC"}},
+	{"Philadelphia", "Pennsylvania", "US", nil},
+	{"Pittsburgh", "Pennsylvania", "US", nil},
+	{"Portland", "Oregon", "US", nil},
+	{"Salt Lake City", "Utah", "US", nil},
+	{"Phoenix", "Arizona", "US", nil},
+	{"Minneapolis", "Minnesota", "US", nil},
+	{"Detroit", "Michigan", "US", nil},
+	{"Raleigh", "North Carolina", "US", []string{"Raleigh-Durham", "Research Triangle"}},
+	{"Nashville", "Tennessee", "US", nil},
+
+	// Canada
+	{"Toronto", "Ontario", "CA", nil},
+	{"Ottawa", "Ontario", "CA", nil},
+	{"Waterloo", "Ontario", "CA", nil},
+	{"Vancouver", "British Columbia", "CA", nil},
+	{"Montreal", "Quebec", "CA", []string{"Montréal"}},
+	{"Calgary", "Alberta", "CA", nil},
+
+	// Latin America
+	{"Mexico City", "", "MX", []string{"Ciudad de México", "CDMX"}},
+	{"Guadalajara", "", "MX", nil},
+	{"São Paulo", "São Paulo", "BR", []string{"Sao Paulo"}},
+	{"Rio de Janeiro", "Rio de Janeiro", "BR", nil},
+	{"Buenos Aires", "", "AR", nil},
+	{"Santiago", "", "CL", nil},
+	{"Bogotá", "", "CO", []string{"Bogota"}},
+	{"Medellín", "", "CO", []string{"Medellin"}},
+	{"Lima", "", "PE", nil},
+	{"Montevideo", "", "UY", nil},
+
+	// Europe
+	{"London", "England", "GB", nil},
+	{"Manchester", "England", "GB", nil},
+	{"Cambridge", "England", "GB", nil},
+	{"Edinburgh", "Scotland", "GB", nil},
+	{"Dublin", "", "IE", nil},
+	{"Paris", "", "FR", nil},
+	{"Lyon", "", "FR", nil},
+	{"Berlin", "Berlin", "DE", nil},
+	{"Munich", "Bavaria", "DE", []string{"München"}},
+	{"Hamburg", "Hamburg", "DE", nil},
+	{"Frankfurt", "Hesse", "DE", []string{"Frankfurt am Main"}},
+	{"Cologne", "North Rhine-Westphalia", "DE", []string{"Köln"}},
+	{"Amsterdam", "", "NL", nil},
+	{"Rotterdam", "", "NL", nil},
+	{"Brussels", "", "BE", []string{"Bruxelles"}},
+	{"Zurich", "", "CH", []string{"Zürich"}},
+	{"Geneva", "", "CH", []string{"Genève"}},
+	{"Vienna", "", "AT", []string{"Wien"}},
+	{"Madrid", "", "ES", nil},
+	{"Barcelona", "", "ES", nil},
+	{"Lisbon", "", "PT", []string{"Lisboa"}},
+	{"Porto", "", "PT", nil},
+	{"Milan", "", "IT", []string{"Milano"}},
+	{"Rome", "", "IT", []string{"Roma"}},
+	{"Stockholm", "", "SE", nil},
+	{"Oslo", "", "NO", nil},
+	{"Copenhagen", "", "DK", []string{"København"}},
+	{"Helsinki", "", "FI", nil},
+	{"Warsaw", "", "PL", []string{"Warszawa"}},
+	{"Kraków", "", "PL", []string{"Krakow", "Cracow"}},
+	{"Prague", "", "CZ", []string{"Praha"}},
+	{"Budapest", "", "HU", nil},
+	{"Bucharest", "", "RO", []string{"București"}},
+	{"Athens", "", "GR", nil},
+	{"Tallinn", "", "EE", nil},
+	{"Kyiv", "", "UA", []string{"Kiev"}},
+	{"Istanbul", "", "TR", nil},
+
+	// Middle East and Africa
+	{"Tel Aviv", "", "IL", []string{"Tel Aviv-Yafo"}},
+	{"Dubai", "", "AE", nil},
+	{"Cairo", "", "EG", nil},
+	{"Lagos", "", "NG", nil},
+	{"Nairobi", "", "KE", nil},
+	{"Cape Town", "", "ZA", nil},
+	{"Johannesburg", "", "ZA", nil},
+
+	// Asia-Pacific
+	{"Bengaluru", "Karnataka", "IN", []string{"Bangalore"}},
+	{"Mumbai", "Maharashtra", "IN", []string{"Bombay"}},
+	{"Pune", "Maharashtra", "IN", nil},
+	{"Hyderabad", "Telangana", "IN", nil},
+	{"Chennai", "Tamil Nadu", "IN", []string{"Madras"}},
+	{"New Delhi", "Delhi", "IN", []string{"Delhi"}},
+	{"Gurugram", "Haryana", "IN", []string{"Gurgaon"}},
+	{"Noida", "Uttar Pradesh", "IN", nil},
+	{"Beijing", "", "CN", nil},
+	{"Shanghai", "", "CN", nil},
+	{"Shenzhen", "", "CN", nil},
+	{"Taipei", "", "TW", nil},
+	{"Tokyo", "", "JP", nil},
+	{"Seoul", "", "KR", nil},
+	{"Kuala Lumpur", "", "MY", nil},
+	{"Bangkok", "", "TH", nil},
+	{"Ho Chi Minh City", "", "VN", []string{"Saigon"}},
+	{"Manila", "", "PH", nil},
+	{"Jakarta", "", "ID", nil},
+	{"Sydney", "New South Wales", "AU", nil},
+	{"Melbourne", "Victoria", "AU", nil},
+	{"Brisbane", "Queensland", "AU", nil},
+	{"Auckland", "", "NZ", nil},
+	{"Wellington", "", "NZ", nil},
+}
+
+// remoteAreas are the multi-country areas remote postings are scoped to,
+// keyed by how they're written.
+var remoteAreas = map[string]string{
+	"worldwide":      RemoteWorldwide,
+	"anywhere":       RemoteWorldwide,
+	"global":         RemoteWorldwide,
+	"eu":             "EU",
+	"european union": "EU",
+	"europe":         "Europe",
+	"emea":           "EMEA",
+	"apac":           "APAC",
+	"asia pacific":   "APAC",
+	"asia-pacific":   "APAC",
+	"latam":          "LATAM",
+	"latin america":  "LATAM",
+	"north america":  "North America",
+	"americas":       "Americas",
+}
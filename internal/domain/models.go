@@ -15,9 +15,54 @@ type Job struct {
 	// NEW
 	Description string
 
+	SalaryRange *SalaryRange // Salary parsed, or inferred from Description; nil if unknown
+	Place       Place        // Location resolved by NormalizeJob
+
 	CreatedAt time.Time
 }
 
+// Normalized work modes; NormalizeWorkMode and NormalizeJob map everything
+// else to "", unknown.
+const (
+	WorkModeRemote = "Remote"
+	WorkModeHybrid = "Hybrid"
+	WorkModeOnSite = "On-site"
+)
+
+// RemoteWorldwide is the RemoteScope of remote jobs open from anywhere.
+const RemoteWorldwide = "Worldwide"
+
+// Place is the structured form of a job's location. Fields the location
+// doesn't name are empty.
+type Place struct {
+	City        string
+	Region      string // state, province…
+	Country     string // ISO 3166-1 alpha-2
+	RemoteScope string // where a remote job can be done from: a country code, an area like "EU" or "LATAM", or RemoteWorldwide
+}
+
+// Pay periods of a SalaryRange.
+const (
+	SalaryPerHour  = "hour"
+	SalaryPerDay   = "day"
+	SalaryPerWeek  = "week"
+	SalaryPerMonth = "month"
+	SalaryPerYear  = "year"
+)
+
+// SalaryRange is the structured form of a job's salary. Min and Max are in
+// Currency per Period (equal for a single figure); the Annual fields convert
+// them to a yearly amount for sorting and filtering.
+type SalaryRange struct {
+	Min       float64
+	Max       float64
+	Currency  string // ISO 4217 code, "" when the text doesn't say
+	Period    string // SalaryPer*
+	AnnualMin float64
+	AnnualMax float64
+	Inferred  bool // read from the description by the LLM, not the salary field
+}
+
 type Application struct {
 	ID            int64
 	JobID         int64
@@ -27,4 +72,109 @@ type Application struct {
 	AppliedOn     *time.Time
 	InterviewTime *time.Time
 	NotionPageID  *string
+	CreatedAt     time.Time
+}
+
+// Enrichment is the LLM's reading of a job description. A job keeps every
+// enrichment it got; the latest one is current.
+type Enrichment struct {
+	ID            int64
+	JobID         int64
+	Summary       string
+	Skills        []string
+	TailoredNote  string
+	Snippet       string
+	Model         string // model that produced it
+	PromptVersion string // version of the prompt it answered
+	CreatedAt     time.Time
+}
+
+// Resume is an uploaded resume, as plain text or markdown. The latest one is
+// current.
+type Resume struct {
+	ID        int64
+	Content   string
+	CreatedAt time.Time
+}
+
+// FitScore is the LLM's rating of how well a resume fits a job. Error is set
+// instead of a score when the model's reply could not be used.
+type FitScore struct {
+	ID            int64
+	JobID         int64
+	ResumeID      int64
+	Score         int // 0-100
+	MatchedSkills []string
+	MissingSkills []string
+	Suggestions   []string
+	Model         string
+	PromptVersion string
+	Error         string
+	CreatedAt     time.Time
+}
+
+// Kinds of Draft.
+const (
+	DraftCoverLetter = "cover_letter"
+	DraftOutreach    = "outreach" // short message to a contact
+)
+
+// Draft is a generated cover letter or outreach message. Each generation for
+// the same application, kind and contact gets the next Version.
+type Draft struct {
+	ID            int64
+	ApplicationID int64
+	ContactID     *int64 // outreach only
+	Kind          string
+	Version       int
+	Tone          string
+	Content       string
+	Model         string
+	PromptVersion string
+	CreatedAt     time.Time
+}
+
+// Contact is a person linked to a job: recruiter, hiring manager, referral…
+type Contact struct {
+	ID         int64
+	JobID      int64
+	Name       string
+	Email      string
+	Role       string
+	Notes      string
+	Source     string  // ContactSource*
+	Status     string  // ContactStatus*
+	Confidence float64 // 0-1; 1 for contacts entered by hand
+}
+
+// Where a contact came from.
+const (
+	ContactSourceManual = "manual"
+	ContactSourceAI     = "ai"    // extracted by the LLM
+	ContactSourceRegex  = "regex" // extracted by pattern matching
+)
+
+// Review status of a contact. Extracted contacts start as suggested.
+const (
+	ContactStatusAccepted  = "accepted"
+	ContactStatusSuggested = "suggested"
+	ContactStatusDiscarded = "discarded"
+)
+
+// Where a change to an application came from.
+const (
+	SourceAPI    = "api"
+	SourceNotion = "notion_sync"
+	SourceCLI    = "cli"
+)
+
+// ApplicationEvent records one change of an application's stage or outcome.
+type ApplicationEvent struct {
+	ID            int64
+	ApplicationID int64
+	Field         string // "stage" or "outcome"
+	OldValue      string
+	NewValue      string
+	Source        string
+	CreatedAt     time.Time
 }
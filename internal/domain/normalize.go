@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// NormalizeJob maps j.WorkMode to a WorkMode* value and resolves j.Location
+// into j.Place. The location fills in the work mode when the field doesn't
+// name one ("Remote - US", "New York, NY (Hybrid)"). A work mode neither can
+// classify ("Flexible", "Field-based") becomes "", unknown; j.Location is
+// kept as written.
+func NormalizeJob(j *Job) {
+	place, mode := NormalizeLocation(j.Location)
+	if m := NormalizeWorkMode(j.WorkMode); m != "" {
+		mode = m
+	}
+	if mode == WorkModeRemote && place.RemoteScope == "" {
+		place.RemoteScope = place.Country
+	}
+	j.WorkMode = mode
+	j.Place = place
+}
+
+var (
+	remoteModes = []string{"remote", "fully remote", "100% remote", "remote first", "work from home", "wfh", "telecommute", "home based", "anywhere"}
+	onSiteModes = []string{"on site", "onsite", "in office", "office", "office based", "in person"}
+)
+
+// NormalizeWorkMode maps the ways postings spell a work mode ("On site",
+// "onsite", "Remote - US", "hybrid remote") to WorkModeRemote, WorkModeHybrid
+// or WorkModeOnSite, and anything else to "".
+func NormalizeWorkMode(s string) string {
+	k := strings.Join(strings.Fields(strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(s))), " ")
+	switch {
+	case k == "":
+		return ""
+	case strings.Contains(k, "hybrid"):
+		return WorkModeHybrid
+	case slices.Contains(remoteModes, k) || strings.HasPrefix(k, "remote "):
+		return WorkModeRemote
+	case slices.Contains(onSiteModes, k):
+		return WorkModeOnSite
+	}
+	return ""
+}
+
+var (
+	parenPattern  = regexp.MustCompile(`\(([^)]*)\)`)
+	remotePattern = regexp.MustCompile(`(?i)\b(fully remote|100% remote|remote|work from home|wfh|telecommute)\b`)
+	fillerPattern = regexp.MustCompile(`(?i)^(in|from|within|based in|only|first|friendly|ok)\b|\b(only|based|first|friendly|ok)$`)
+	splitPattern  = regexp.MustCompile(`\s*(?:[,;/|·]|\s[-–—]\s)\s*`)
+
+	// "Greater Seattle Area", "San Francisco Bay Area", "New York City
+	// Metropolitan Area" name the city they surround.
+	areaPrefixes = []string{"greater "}
+	areaSuffixes = []string{" metropolitan area", " metro area", " bay area", " metroplex", " area", " metro"}
+)
+
+// NormalizeLocation resolves a scraped location ("San Francisco, CA",
+// "Berlin, Berlin, Germany", "Remote - US", "EMEA") against the bundled
+// gazetteer. mode is the work mode the location states, if any. Parts the
+// gazetteer doesn't know are kept as the city when the country is known.
+func NormalizeLocation(s string) (p Place, mode string) {
+	s = strings.TrimSpace(s)
+
+	// "(Hybrid)" and "(On-site)" are LinkedIn's work-mode suffixes; other
+	// parentheses ("Remote (US)") hold part of the place.
+	s = parenPattern.ReplaceAllStringFunc(s, func(m string) string {
+		inner := m[1 : len(m)-1]
+		if wm := NormalizeWorkMode(inner); wm != "" {
+			mode = wm
+			return ""
+		}
+		return ", " + inner
+	})
+	if remotePattern.MatchString(s) {
+		mode = WorkModeRemote
+		s = remotePattern.ReplaceAllString(s, "")
+	}
+
+	var parts []string
+	for _, part := range splitPattern.Split(s, -1) {
+		part = strings.Trim(part, " -–—.,")
+		part = strings.TrimSpace(fillerPattern.ReplaceAllString(part, ""))
+		if part == "" {
+			continue
+		}
+		if area, ok := remoteAreas[gazKey(part)]; ok {
+			p.RemoteScope = area
+			if area == RemoteWorldwide {
+				mode = WorkModeRemote
+			}
+			continue
+		}
+		if wm := NormalizeWorkMode(part); wm != "" {
+			mode = wm
+			continue
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return p, mode
+	}
+
+	// The country comes last. A bare code only counts as one when it can't
+	// be a region: "San Francisco, CA" is California, "CA" alone is Canada.
+	last := parts[len(parts)-1]
+	if c := lookupCountry(last, len(parts) == 1 || lookupRegion(last, "") == nil); c != nil {
+		p.Country = c.code
+		parts = parts[:len(parts)-1]
+	}
+
+	switch len(parts) {
+	case 0:
+	case 1:
+		// "Berlin, Germany" names a city, "California, United States" a
+		// region.
+		if c := lookupCity(parts[0], "", p.Country); c != nil {
+			p.City, p.Region, p.Country = c.name, c.region, c.country
+		} else if r := lookupRegion(parts[0], p.Country); r != nil {
+			p.Region, p.Country = r.name, r.country
+		} else if p.Country != "" {
+			p.City = parts[0]
+		}
+	default:
+		// "Austin, TX", "Munich, Bavaria, Germany"; earlier parts are
+		// neighborhoods.
+		region, city := parts[len(parts)-1], parts[len(parts)-2]
+		if r := lookupRegion(region, p.Country); r != nil {
+			p.Region, p.Country = r.name, r.country
+		} else if c := lookupCity(region, "", p.Country); c != nil {
+			city = region
+		}
+		if c := lookupCity(city, p.Region, p.Country); c != nil {
+			p.City, p.Region, p.Country = c.name, c.region, c.country
+		} else if p.Country != "" {
+			p.City = city
+		}
+	}
+	return p, mode
+}
+
+// gazKey is the lookup form of a place name: lower case, single spaces.
+func gazKey(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// isCode reports whether s is written like an abbreviation ("CA", "NSW").
+func isCode(s string) bool {
+	if len(s) < 2 || len(s) > 3 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func lookupCountry(s string, allowCode bool) *gazCountry {
+	k := gazKey(s)
+	for i := range countries {
+		c := &countries[i]
+		if allowCode && isCode(s) && c.code == s {
+			return c
+		}
+		if gazKey(c.name) == k || slices.ContainsFunc(c.aliases, func(a string) bool { return gazKey(a) == k }) {
+			return c
+		}
+	}
+	return nil
+}
+
+// lookupRegion finds a region by name or code, within country when set.
+func lookupRegion(s, country string) *gazRegion {
+	k := gazKey(s)
+	for i := range regions {
+		r := &regions[i]
+		if country != "" && r.country != country {
+			continue
+		}
+		if gazKey(r.name) == k || (isCode(s) && r.code == s) {
+			return r
+		}
+	}
+	return nil
+}
+
+// lookupCity finds a city by name or alias, also trying the name without
+// "Greater … Area" wording, within region and country when set.
+func lookupCity(s, region, country string) *gazCity {
+	keys := []string{gazKey(s)}
+	k := keys[0]
+	for _, pre := range areaPrefixes {
+		k = strings.TrimPrefix(k, pre)
+	}
+	for _, suf := range areaSuffixes {
+		k = strings.TrimSuffix(k, suf)
+	}
+	keys = append(keys, k)
+
+	for i := range cities {
+		c := &cities[i]
+		if (country != "" && c.country != country) || (region != "" && c.region != "" && c.region != region) {
+			continue
+		}
+		for _, k := range keys {
+			if gazKey(c.name) == k || slices.ContainsFunc(c.aliases, func(a string) bool { return gazKey(a) == k }) {
+				return c
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "testing"
+
+func TestNormalizeJob(t *testing.T) {
+	tests := []struct {
+		location, workMode string
+		wantMode           string
+		want               Place
+	}{
+		{"Remote - US", "", WorkModeRemote, Place{Country: "US", RemoteScope: "US"}},
+		{"Remote-US", "", WorkModeRemote, Place{Country: "US", RemoteScope: "US"}},
+		{"Remote", "Remote", WorkModeRemote, Place{}},
+		{"San Francisco, CA", "On site", WorkModeOnSite, Place{City: "San Francisco", Region: "California", Country: "US"}},
+		{"New York, NY (Hybrid)", "", WorkModeHybrid, Place{City: "New York", Region: "New York", Country: "US"}},
+		{"Berlin, Berlin, Germany", "onsite", WorkModeOnSite, Place{City: "Berlin", Region: "Berlin", Country: "DE"}},
+		{"London, England, United Kingdom", "Hybrid", WorkModeHybrid, Place{City: "London", Region: "England", Country: "GB"}},
+		{"Toronto, ON", "", "", Place{City: "Toronto", Region: "Ontario", Country: "CA"}},
+		{"Greater Seattle Area", "", "", Place{City: "Seattle", Region: "Washington", Country: "US"}},
+		{"San Francisco Bay Area", "", "", Place{City: "San Francisco", Region: "California", Country: "US"}},
+		{"New York City Metropolitan Area", "", "", Place{City: "New York", Region: "New York", Country: "US"}},
+		{"Dallas-Fort Worth Metroplex", "", "", Place{City: "Dallas", Region: "Texas", Country: "US"}},
+		{"United States (Remote)", "", WorkModeRemote, Place{Country: "US", RemoteScope: "US"}},
+		{"Remote (Canada)", "", WorkModeRemote, Place{Country: "CA", RemoteScope: "CA"}},
+		{"Remote in Germany", "", WorkModeRemote, Place{Country: "DE", RemoteScope: "DE"}},
+		{"Remote, Europe", "", WorkModeRemote, Place{RemoteScope: "Europe"}},
+		{"EMEA", "Remote", WorkModeRemote, Place{RemoteScope: "EMEA"}},
+		{"Anywhere", "", WorkModeRemote, Place{RemoteScope: RemoteWorldwide}},
+		{"Austin, TX", "In-Office", WorkModeOnSite, Place{City: "Austin", Region: "Texas", Country: "US"}},
+		{"California, United States", "", "", Place{Region: "California", Country: "US"}},
+		{"Munich, Bavaria, Germany", "", "", Place{City: "Munich", Region: "Bavaria", Country: "DE"}},
+		{"Springfield, IL", "", "", Place{City: "Springfield", Region: "Illinois", Country: "US"}},
+		{"Shoreditch, London, UK", "", "", Place{City: "London", Region: "England", Country: "GB"}},
+		{"Bengaluru, Karnataka, India", "", "", Place{City: "Bengaluru", Region: "Karnataka", Country: "IN"}},
+		{"Sao Paulo, Brazil", "", "", Place{City: "São Paulo", Region: "São Paulo", Country: "BR"}},
+		{"Paris", "", "", Place{City: "Paris", Country: "FR"}},
+		{"CA", "", "", Place{Country: "CA"}}, // a bare code is a country, not California
+		{"", "", "", Place{}},
+
+		// Work modes that can't be classified are unknown.
+		{"Somewhere", "Contract", "", Place{}},
+		{"Paris", "Flexible", "", Place{City: "Paris", Country: "FR"}},
+		{"Ohio", "Field-based", "", Place{Region: "Ohio", Country: "US"}},
+		{"Toronto, ON (Hybrid)", "Full-time", WorkModeHybrid, Place{City: "Toronto", Region: "Ontario", Country: "CA"}},
+	}
+	for _, tt := range tests {
+		j := Job{Location: tt.location, WorkMode: tt.workMode}
+		NormalizeJob(&j)
+		if j.WorkMode != tt.wantMode || j.Place != tt.want {
+			t.Errorf("NormalizeJob(%q, %q) = %q %+v, want %q %+v",
+				tt.location, tt.workMode, j.WorkMode, j.Place, tt.wantMode, tt.want)
+		}
+		if j.Location != tt.location {
+			t.Errorf("NormalizeJob changed location %q to %q", tt.location, j.Location)
+		}
+	}
+}
+
+func TestNormalizeWorkMode(t *testing.T) {
+	tests := map[string]string{
+		"Remote":        WorkModeRemote,
+		"fully remote":  WorkModeRemote,
+		"Remote - US":   WorkModeRemote,
+		"WFH":           WorkModeRemote,
+		"Hybrid":        WorkModeHybrid,
+		"hybrid remote": WorkModeHybrid,
+		"On-site":       WorkModeOnSite,
+		"On site":       WorkModeOnSite,
+		"onsite":        WorkModeOnSite,
+		"in_office":     WorkModeOnSite,
+		"":              "",
+		"Flexible":      "",
+		"Full-time":     "",
+	}
+	for in, want := range tests {
+		if got := NormalizeWorkMode(in); got != want {
+			t.Errorf("NormalizeWorkMode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
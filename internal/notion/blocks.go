@@ -0,0 +1,114 @@
+package notion
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	gnt "github.com/dstotijn/go-notion"
+
+	"jobflow.local/internal/domain"
+)
+
+// Notion API limits.
+const (
+	maxTextLen          = 2000 // characters per rich-text object
+	maxBlocksPerRequest = 100  // children per create/append call
+)
+
+// splitText cuts s into pieces of at most max characters, preferring to
+// break after whitespace so words stay whole.
+func splitText(s string, max int) []string {
+	var out []string
+	for utf8.RuneCountInString(s) > max {
+		// Byte offset of the rune just past the limit.
+		cut := len(s)
+		n := 0
+		for i := range s {
+			if n == max {
+				cut = i
+				break
+			}
+			n++
+		}
+		if i := strings.LastIndexAny(s[:cut], " \n\t"); i > cut/2 {
+			cut = i + 1
+		}
+		out = append(out, s[:cut])
+		s = s[cut:]
+	}
+	if s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+// rtChunks is rt for text that may exceed the per-object limit: it returns
+// as many rich-text objects as needed.
+func rtChunks(text string) []gnt.RichText {
+	var out []gnt.RichText
+	for _, part := range splitText(text, maxTextLen) {
+		out = append(out, rt(part))
+	}
+	return out
+}
+
+func heading(text string) gnt.Block {
+	return gnt.Heading2Block{RichText: []gnt.RichText{rt(text)}}
+}
+
+// paragraphs turns text into paragraph blocks: one per blank-line separated
+// paragraph, split further when a paragraph is over the length limit.
+func paragraphs(text string) []gnt.Block {
+	var blocks []gnt.Block
+	for _, para := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		for _, part := range splitText(para, maxTextLen) {
+			blocks = append(blocks, gnt.ParagraphBlock{RichText: []gnt.RichText{rt(part)}})
+		}
+	}
+	return blocks
+}
+
+// buildPageBlocks lays out the page body: the AI enrichment, then the full
+// description.
+func buildPageBlocks(job domain.Job, enr *domain.Enrichment) []gnt.Block {
+	blocks := enrichmentBlocks(enr)
+
+	if job.Description != "" {
+		blocks = append(blocks, heading("Job description"))
+		blocks = append(blocks, paragraphs(job.Description)...)
+	}
+
+	return blocks
+}
+
+// enrichmentBlocks renders the AI summary, a bulleted list of skills and a
+// callout with the tailored note.
+func enrichmentBlocks(enr *domain.Enrichment) []gnt.Block {
+	if enr == nil {
+		return nil
+	}
+
+	var blocks []gnt.Block
+	if enr.Summary != "" {
+		blocks = append(blocks, heading("AI summary"))
+		blocks = append(blocks, paragraphs(enr.Summary)...)
+	}
+	if len(enr.Skills) > 0 {
+		blocks = append(blocks, heading("Key skills"))
+		for _, sk := range enr.Skills {
+			blocks = append(blocks, gnt.BulletedListItemBlock{RichText: rtChunks(sk)})
+		}
+	}
+	if enr.TailoredNote != "" {
+		emoji := "💡"
+		blocks = append(blocks, gnt.CalloutBlock{
+			RichText: rtChunks(enr.TailoredNote),
+			Icon:     &gnt.Icon{Type: gnt.IconTypeEmoji, Emoji: &emoji},
+		})
+	}
+	return blocks
+}
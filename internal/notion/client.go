@@ -2,23 +2,69 @@
 package notion
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 
 	gnt "github.com/dstotijn/go-notion"
 
 	"jobflow.local/internal/domain"
 )
 
+// The Notion API endpoint and version go-notion uses, for the requests it
+// can't make (see updatePage).
+const (
+	apiBaseURL = "https://api.notion.com/v1"
+	apiVersion = "2022-06-28"
+)
+
 type Client struct {
 	api        *gnt.Client
+	http       *http.Client // same transport as api
+	token      string
 	databaseID string
+	mapping    Mapping
+	transport  *transport
+
+	mu              sync.Mutex
+	optionalPresent map[string]bool // optionalFields found by the last CheckSchema
 }
 
-func New(token, databaseID string) *Client {
-	return &Client{
-		api:        gnt.NewClient(token),
+// Option customizes a Client built by New.
+type Option func(*Client)
+
+// WithMapping replaces DefaultMapping with a custom field → property mapping.
+func WithMapping(m Mapping) Option {
+	return func(c *Client) {
+		c.mapping = m
+	}
+}
+
+func New(token, databaseID string, opts ...Option) *Client {
+	t := newTransport(http.DefaultTransport)
+	hc := &http.Client{Transport: t}
+	c := &Client{
+		api:        gnt.NewClient(token, gnt.WithHTTPClient(hc)),
+		http:       hc,
+		token:      token,
 		databaseID: databaseID,
+		mapping:    DefaultMapping(),
+		transport:  t,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
+}
+
+// Stats returns the retry and throttling counters of the client's transport.
+func (c *Client) Stats() Stats {
+	return c.transport.stats()
 }
 
 // Ping just tries a tiny QueryDatabase to see if the DB is reachable.
@@ -52,6 +98,12 @@ func (c *Client) SearchDatabases(ctx context.Context) ([]gnt.Database, error) {
 	return dbs, nil
 }
 
+// IsNotFound reports whether err means the page or database doesn't exist
+// (or isn't shared with the integration).
+func IsNotFound(err error) bool {
+	return errors.Is(err, gnt.ErrObjectNotFound)
+}
+
 // rt builds a valid Notion RichText node for plain text.
 func rt(text string) gnt.RichText {
 	return gnt.RichText{
@@ -62,98 +114,217 @@ func rt(text string) gnt.RichText {
 	}
 }
 
-func buildJobPageProperties(job domain.Job, app domain.Application) gnt.DatabasePageProperties {
-	props := gnt.DatabasePageProperties{}
+// buildJobPageProperties maps a job and its application onto the tracker
+// properties configured in c.mapping.
+func (c *Client) buildJobPageProperties(job domain.Job, app domain.Application) gnt.DatabasePageProperties {
+	props := c.mapping.properties(c.writableFields(jobFields), jobValues(job))
 
-	if job.Title != "" {
-		props["Position"] = gnt.DatabasePageProperty{
-			Title: []gnt.RichText{
-				rt(job.Title),
-			},
-		}
-	}
-	if job.Company != "" {
-		props["Company"] = gnt.DatabasePageProperty{
-			RichText: []gnt.RichText{
-				rt(job.Company),
-			},
-		}
-	}
-	if job.URL != "" {
-		props["Job Posting"] = gnt.DatabasePageProperty{
-			URL: &job.URL,
-		}
-	}
-	if job.WorkMode != "" {
-		props["Work Mode"] = gnt.DatabasePageProperty{
-			Select: &gnt.SelectOptions{
-				Name: job.WorkMode,
-			},
-		}
-	}
-	if job.Location != "" {
-		props["location"] = gnt.DatabasePageProperty{
-			RichText: []gnt.RichText{
-				rt(job.Location),
-			},
-		}
+	// (No Description mapping here, since your DB has no "Description" property)
+
+	for name, prop := range c.buildApplicationProperties(app) {
+		props[name] = prop
 	}
-	if job.Salary != "" {
-		props["Salary"] = gnt.DatabasePageProperty{
-			RichText: []gnt.RichText{
-				rt(job.Salary),
-			},
+
+	return props
+}
+
+// writableFields drops the optional fields whose property the tracker
+// doesn't have.
+func (c *Client) writableFields(fields []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !optionalFields[f] || c.optionalPresent[f] {
+			out = append(out, f)
 		}
 	}
+	return out
+}
 
-	// (No Description mapping here, since your DB has no "Description" property)
+// buildApplicationProperties maps the pipeline fields an application can
+// change after the page exists (stage, outcome, notes, next interview).
+func (c *Client) buildApplicationProperties(app domain.Application) gnt.DatabasePageProperties {
+	return c.mapping.properties(applicationFields, applicationValues(app))
+}
 
-	if app.Stage != "" {
-		props["Stage"] = gnt.DatabasePageProperty{
-			Select: &gnt.SelectOptions{
-				Name: app.Stage,
-			},
-		}
+// plainText joins the plain text of a rich-text property.
+func plainText(parts []gnt.RichText) string {
+	var b strings.Builder
+	for _, p := range parts {
+		b.WriteString(p.PlainText)
 	}
-	if app.Outcome != "" {
-		props["Outcome"] = gnt.DatabasePageProperty{
-			Select: &gnt.SelectOptions{
-				Name: app.Outcome,
-			},
-		}
+	return b.String()
+}
+
+// jobFromProperties is the reverse of the job half of
+// buildJobPageProperties. The description lives in the page body and is not
+// read back.
+func (c *Client) jobFromProperties(props gnt.DatabasePageProperties) domain.Job {
+	v := c.mapping.values(props, jobFields)
+
+	return domain.Job{
+		Title:    v[FieldTitle],
+		Company:  v[FieldCompany],
+		URL:      v[FieldURL],
+		WorkMode: v[FieldWorkMode],
+		Location: v[FieldLocation],
+		Salary:   v[FieldSalary],
 	}
-	if app.Notes != "" {
-		props["Notes"] = gnt.DatabasePageProperty{
-			RichText: []gnt.RichText{
-				rt(app.Notes),
-			},
-		}
+}
+
+// applicationFromProperties is the reverse of buildApplicationProperties:
+// it reads the pipeline fields back from a tracker page.
+func (c *Client) applicationFromProperties(props gnt.DatabasePageProperties) domain.Application {
+	v := c.mapping.values(props, applicationFields)
+
+	app := domain.Application{
+		Stage:   v[FieldStage],
+		Outcome: v[FieldOutcome],
+		Notes:   v[FieldNotes],
 	}
-	if app.InterviewTime != nil {
-		dt := gnt.NewDateTime(*app.InterviewTime, true)
-		props["Next Interview"] = gnt.DatabasePageProperty{
-			Date: &gnt.Date{
-				Start: dt,
-			},
+	if s, ok := v[FieldNextInterview]; ok {
+		if t, err := parseDateValue(s); err == nil {
+			app.InterviewTime = &t
 		}
 	}
-
-	return props
+	return app
 }
 
-// CreateJobPage: create a new row in the Job Tracker DB.
-func (c *Client) CreateJobPage(ctx context.Context, job domain.Job, app domain.Application) (string, error) {
-	props := buildJobPageProperties(job, app)
+// CreateJobPage: create a new row in the Job Tracker DB. The page body gets
+// the AI enrichment (if any) and the full job description as blocks.
+func (c *Client) CreateJobPage(ctx context.Context, job domain.Job, app domain.Application, enr *domain.Enrichment) (string, error) {
+	props := c.buildJobPageProperties(job, app)
+	blocks := buildPageBlocks(job, enr)
+
+	first := blocks
+	if len(first) > maxBlocksPerRequest {
+		first = first[:maxBlocksPerRequest]
+	}
 
 	params := gnt.CreatePageParams{
 		ParentType:             gnt.ParentTypeDatabase,
 		ParentID:               c.databaseID,
 		DatabasePageProperties: &props,
+		Children:               first,
 	}
 
 	page, err := c.api.CreatePage(ctx, params)
 	if err != nil {
 		return "", err
 	}
+
+	// Notion caps children per request; append the rest in batches.
+	if err := c.appendBlocks(ctx, page.ID, blocks[len(first):]); err != nil {
+		return page.ID, fmt.Errorf("append page body: %w", err)
+	}
 	return page.ID, nil
 }
+
+// AppendEnrichment adds the AI enrichment to the end of an existing page's
+// body, for pages created before enrichment finished.
+func (c *Client) AppendEnrichment(ctx context.Context, pageID string, enr domain.Enrichment) error {
+	return c.appendBlocks(ctx, pageID, enrichmentBlocks(&enr))
+}
+
+// appendBlocks appends blocks to a page in batches of maxBlocksPerRequest.
+func (c *Client) appendBlocks(ctx context.Context, pageID string, blocks []gnt.Block) error {
+	for len(blocks) > 0 {
+		n := min(len(blocks), maxBlocksPerRequest)
+		if _, err := c.api.AppendBlockChildren(ctx, pageID, blocks[:n]); err != nil {
+			return err
+		}
+		blocks = blocks[n:]
+	}
+	return nil
+}
+
+// UpdateApplicationPage pushes an application's pipeline fields to its
+// existing page. Empty fields are cleared in Notion, so the sync doesn't
+// read the old value back as a remote edit.
+func (c *Client) UpdateApplicationPage(ctx context.Context, pageID string, app domain.Application) error {
+	return c.updatePage(ctx, pageID, c.buildApplicationProperties(app), c.emptyApplicationProperties(app))
+}
+
+// emptyApplicationProperties returns the clearing values for app's empty
+// pipeline fields.
+func (c *Client) emptyApplicationProperties(app domain.Application) map[string]json.RawMessage {
+	return c.mapping.emptyProperties(applicationFields, applicationValues(app))
+}
+
+// updatePage sets props on an existing page and clears the properties in
+// empty. go-notion drops empty values when encoding, so an update that
+// clears anything is sent directly.
+func (c *Client) updatePage(ctx context.Context, pageID string, props gnt.DatabasePageProperties, empty map[string]json.RawMessage) error {
+	if len(empty) == 0 {
+		if len(props) == 0 {
+			return nil
+		}
+		_, err := c.api.UpdatePage(ctx, pageID, gnt.UpdatePageParams{
+			DatabasePageProperties: props,
+		})
+		return err
+	}
+
+	all := make(map[string]any, len(props)+len(empty))
+	for name, prop := range props {
+		all[name] = prop
+	}
+	for name, v := range empty {
+		all[name] = v
+	}
+	body, err := json.Marshal(map[string]any{"properties": all})
+	if err != nil {
+		return fmt.Errorf("encode page properties: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, apiBaseURL+"/pages/"+pageID, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Notion-Version", apiVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("notion: failed to make HTTP request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		// Decoded like go-notion's errors, so IsNotFound works on them.
+		apiErr := &gnt.APIError{Status: res.StatusCode}
+		_ = json.NewDecoder(res.Body).Decode(apiErr)
+		return fmt.Errorf("notion: failed to update page properties: %w", apiErr)
+	}
+	return nil
+}
+
+// UpdateSalary pushes a job's annualized salary to the number properties of
+// its existing page, for salaries inferred after the page was written. It
+// does nothing when the tracker has no such properties.
+func (c *Client) UpdateSalary(ctx context.Context, pageID string, job domain.Job) error {
+	props := c.mapping.properties(c.writableFields([]string{FieldSalaryMin, FieldSalaryMax}), jobValues(job))
+	if len(props) == 0 {
+		return nil
+	}
+
+	_, err := c.api.UpdatePage(ctx, pageID, gnt.UpdatePageParams{
+		DatabasePageProperties: props,
+	})
+	return err
+}
+
+// UpdateJobPage rewrites the properties of an existing row in place, so
+// re-sending a job doesn't add a duplicate row.
+func (c *Client) UpdateJobPage(ctx context.Context, pageID string, job domain.Job, app domain.Application) error {
+	empty := c.emptyApplicationProperties(app)
+	// An unknown work mode is stored empty; clear the select too so the page
+	// doesn't keep the variant it was created with.
+	for name, v := range c.mapping.emptyProperties(c.writableFields([]string{FieldWorkMode}), jobValues(job)) {
+		empty[name] = v
+	}
+	return c.updatePage(ctx, pageID, c.buildJobPageProperties(job, app), empty)
+}
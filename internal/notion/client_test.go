@@ -0,0 +1,98 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"jobflow.local/internal/domain"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestUpdateApplicationPageSendsClears(t *testing.T) {
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	c := &Client{
+		mapping: DefaultMapping(),
+		token:   "secret",
+		http: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method != http.MethodPatch || r.URL.String() != apiBaseURL+"/pages/page-1" {
+				t.Errorf("request = %s %s", r.Method, r.URL)
+			}
+			if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Notion-Version") == "" {
+				t.Errorf("missing auth or version headers: %v", r.Header)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+		})},
+	}
+
+	err := c.UpdateApplicationPage(context.Background(), "page-1", domain.Application{Stage: "Applied"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(got.Properties["Stage"]), `"Applied"`) {
+		t.Errorf("Stage = %s, want the Applied option", got.Properties["Stage"])
+	}
+	for name, want := range map[string]string{
+		"Outcome":        `{"select":null}`,
+		"Notes":          `{"rich_text":[]}`,
+		"Next Interview": `{"date":null}`,
+	} {
+		if string(got.Properties[name]) != want {
+			t.Errorf("%s = %s, want %s", name, got.Properties[name], want)
+		}
+	}
+}
+
+func TestUpdatePageReportsNotFound(t *testing.T) {
+	c := &Client{
+		mapping: DefaultMapping(),
+		http: &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			body := `{"object":"error","status":404,"code":"object_not_found","message":"Could not find page"}`
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(body))}, nil
+		})},
+	}
+
+	err := c.UpdateApplicationPage(context.Background(), "gone", domain.Application{})
+	if !IsNotFound(err) {
+		t.Fatalf("err = %v, want a not-found error", err)
+	}
+}
+
+func TestUpdateJobPageClearsUnknownWorkMode(t *testing.T) {
+	var got struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	c := &Client{
+		mapping: DefaultMapping(),
+		http: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+		})},
+	}
+
+	job := domain.Job{Title: "Go Engineer", Company: "Acme"}
+	if err := c.UpdateJobPage(context.Background(), "page-1", job, domain.Application{Stage: "Applied"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got.Properties["Work Mode"]) != `{"select":null}` {
+		t.Errorf("Work Mode = %s, want it cleared", got.Properties["Work Mode"])
+	}
+	if _, ok := got.Properties["Salary"]; ok {
+		t.Errorf("Salary = %s, want it left alone", got.Properties["Salary"])
+	}
+}
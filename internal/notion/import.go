@@ -0,0 +1,111 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+
+	gnt "github.com/dstotijn/go-notion"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// Importer copies the rows of an existing tracker database into SQLite.
+type Importer struct {
+	client *Client
+	store  *store.Store
+}
+
+// ImportResult summarizes one import run.
+type ImportResult struct {
+	Pages    int // pages read from the tracker
+	Imported int // applications inserted (or, in a dry run, that would be)
+	Skipped  int // pages already linked to an application
+}
+
+func NewImporter(c *Client, st *store.Store) *Importer {
+	return &Importer{
+		client: c,
+		store:  st,
+	}
+}
+
+// Run pages through the whole tracker and inserts a job and an application
+// for every page no application is linked to yet, so running it again only
+// picks up new pages. With dryRun set nothing is written.
+func (im *Importer) Run(ctx context.Context, dryRun bool) (ImportResult, error) {
+	var res ImportResult
+
+	links, err := im.store.ListNotionLinks(ctx)
+	if err != nil {
+		return res, fmt.Errorf("list linked applications: %w", err)
+	}
+	linked := map[string]bool{}
+	for _, l := range links {
+		linked[pageKey(l.PageID)] = true
+	}
+
+	query := &gnt.DatabaseQuery{
+		Sorts: []gnt.DatabaseQuerySort{
+			{Timestamp: gnt.SortTimeStampCreatedTime, Direction: gnt.SortDirAsc},
+		},
+		PageSize: 100,
+	}
+	for {
+		resp, err := im.client.api.QueryDatabase(ctx, im.client.databaseID, query)
+		if err != nil {
+			return res, fmt.Errorf("query database: %w", err)
+		}
+
+		for _, page := range resp.Results {
+			res.Pages++
+			if linked[pageKey(page.ID)] {
+				res.Skipped++
+				continue
+			}
+			if dryRun {
+				res.Imported++
+				continue
+			}
+
+			ok, err := im.importPage(ctx, page)
+			if err != nil {
+				return res, fmt.Errorf("page %s: %w", page.ID, err)
+			}
+			if ok {
+				res.Imported++
+			} else {
+				res.Skipped++
+			}
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			break
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+	return res, nil
+}
+
+// importPage builds the job and application of one page and inserts them.
+func (im *Importer) importPage(ctx context.Context, page gnt.Page) (bool, error) {
+	props, ok := page.Properties.(gnt.DatabasePageProperties)
+	if !ok {
+		return false, nil
+	}
+
+	job := im.client.jobFromProperties(props)
+	domain.NormalizeJob(&job)
+	// The extension uses the posting URL as external_id; pages without one
+	// get an id of their own so they never merge with another job.
+	job.ExternalID = job.URL
+	if job.ExternalID == "" {
+		job.ExternalID = "notion:" + page.ID
+	}
+
+	app := im.client.applicationFromProperties(props)
+	app.NotionPageID = &page.ID
+	app.CreatedAt = page.CreatedTime
+
+	return im.store.ImportNotionApplication(ctx, &job, &app)
+}
@@ -0,0 +1,350 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	gnt "github.com/dstotijn/go-notion"
+
+	"jobflow.local/internal/domain"
+)
+
+// Domain fields that can be mapped onto tracker properties.
+const (
+	FieldTitle         = "title"
+	FieldCompany       = "company"
+	FieldURL           = "url"
+	FieldWorkMode      = "work_mode"
+	FieldLocation      = "location"
+	FieldSalary        = "salary"
+	FieldSalaryMin     = "salary_min" // annualized, see domain.SalaryRange
+	FieldSalaryMax     = "salary_max"
+	FieldStage         = "stage"
+	FieldOutcome       = "outcome"
+	FieldNotes         = "notes"
+	FieldNextInterview = "next_interview"
+)
+
+// jobFields come from domain.Job, applicationFields from domain.Application.
+var (
+	jobFields         = []string{FieldTitle, FieldCompany, FieldURL, FieldWorkMode, FieldLocation, FieldSalary, FieldSalaryMin, FieldSalaryMax}
+	applicationFields = []string{FieldStage, FieldOutcome, FieldNotes, FieldNextInterview}
+)
+
+// optionalFields are only written when the tracker has their property:
+// CheckSchema doesn't report them missing and records the ones it finds.
+var optionalFields = map[string]bool{
+	FieldSalaryMin: true,
+	FieldSalaryMax: true,
+}
+
+// Notion property types a field can be written as.
+const (
+	TypeTitle       = "title"
+	TypeRichText    = "rich_text"
+	TypeSelect      = "select"
+	TypeMultiSelect = "multi_select"
+	TypeURL         = "url"
+	TypeDate        = "date"
+	TypeNumber      = "number"
+)
+
+var propertyTypes = map[string]bool{
+	TypeTitle:       true,
+	TypeRichText:    true,
+	TypeSelect:      true,
+	TypeMultiSelect: true,
+	TypeURL:         true,
+	TypeDate:        true,
+	TypeNumber:      true,
+}
+
+// PropertyMapping names the Notion property a field goes to and its type.
+type PropertyMapping struct {
+	Property string `json:"property"`
+	Type     string `json:"type"`
+}
+
+// Mapping maps domain fields (FieldTitle, …) to tracker properties.
+// A field missing from the map is not written.
+type Mapping map[string]PropertyMapping
+
+// DefaultMapping matches the original Job Tracker template.
+func DefaultMapping() Mapping {
+	return Mapping{
+		FieldTitle:         {Property: "Position", Type: TypeTitle},
+		FieldCompany:       {Property: "Company", Type: TypeRichText},
+		FieldURL:           {Property: "Job Posting", Type: TypeURL},
+		FieldWorkMode:      {Property: "Work Mode", Type: TypeSelect},
+		FieldLocation:      {Property: "location", Type: TypeRichText},
+		FieldSalary:        {Property: "Salary", Type: TypeRichText},
+		FieldSalaryMin:     {Property: "Salary Min", Type: TypeNumber},
+		FieldSalaryMax:     {Property: "Salary Max", Type: TypeNumber},
+		FieldStage:         {Property: "Stage", Type: TypeSelect},
+		FieldOutcome:       {Property: "Outcome", Type: TypeSelect},
+		FieldNotes:         {Property: "Notes", Type: TypeRichText},
+		FieldNextInterview: {Property: "Next Interview", Type: TypeDate},
+	}
+}
+
+// LoadMapping reads a JSON mapping file and lays it over DefaultMapping.
+// An entry with an empty "property" turns that field off.
+//
+//	{
+//	  "title":    {"property": "Role",   "type": "title"},
+//	  "location": {"property": "Where",  "type": "select"},
+//	  "salary":   {"property": ""}
+//	}
+func LoadMapping(path string) (Mapping, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides Mapping
+	if err := json.Unmarshal(b, &overrides); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	m := DefaultMapping()
+	for field, pm := range overrides {
+		if pm.Property == "" {
+			delete(m, field)
+			continue
+		}
+		if pm.Type == "" {
+			pm.Type = m[field].Type
+		}
+		m[field] = pm
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Validate checks that every field and type is known and that no two fields
+// share a property.
+func (m Mapping) Validate() error {
+	known := map[string]bool{}
+	for _, f := range jobFields {
+		known[f] = true
+	}
+	for _, f := range applicationFields {
+		known[f] = true
+	}
+
+	seen := map[string]string{}
+	titles := 0
+	for field, pm := range m {
+		if !known[field] {
+			return fmt.Errorf("unknown field %q", field)
+		}
+		if !propertyTypes[pm.Type] {
+			return fmt.Errorf("field %q: unsupported type %q", field, pm.Type)
+		}
+		if other, ok := seen[pm.Property]; ok {
+			return fmt.Errorf("fields %q and %q both map to property %q", other, field, pm.Property)
+		}
+		seen[pm.Property] = field
+		if pm.Type == TypeTitle {
+			titles++
+		}
+	}
+	if titles > 1 {
+		return fmt.Errorf("only one field can be mapped as %q", TypeTitle)
+	}
+	return nil
+}
+
+// jobValues returns the mappable job fields as text.
+func jobValues(job domain.Job) map[string]string {
+	v := map[string]string{
+		FieldTitle:    job.Title,
+		FieldCompany:  job.Company,
+		FieldURL:      job.URL,
+		FieldWorkMode: job.WorkMode,
+		FieldLocation: job.Location,
+		FieldSalary:   job.Salary,
+	}
+	if r := job.SalaryRange; r != nil {
+		v[FieldSalaryMin] = strconv.FormatFloat(r.AnnualMin, 'f', -1, 64)
+		v[FieldSalaryMax] = strconv.FormatFloat(r.AnnualMax, 'f', -1, 64)
+	}
+	return v
+}
+
+// applicationValues returns the mappable application fields as text.
+func applicationValues(app domain.Application) map[string]string {
+	v := map[string]string{
+		FieldStage:   app.Stage,
+		FieldOutcome: app.Outcome,
+		FieldNotes:   app.Notes,
+	}
+	if app.InterviewTime != nil {
+		v[FieldNextInterview] = app.InterviewTime.Format(time.RFC3339)
+	}
+	return v
+}
+
+// properties encodes the given fields of values. Empty values are skipped,
+// as are values that don't fit the target type (e.g. "$100k" as a number).
+func (m Mapping) properties(fields []string, values map[string]string) gnt.DatabasePageProperties {
+	props := gnt.DatabasePageProperties{}
+	for _, field := range fields {
+		pm, ok := m[field]
+		if !ok {
+			continue
+		}
+		v := values[field]
+		if v == "" {
+			continue
+		}
+		if prop, ok := encodeProperty(pm.Type, v); ok {
+			props[pm.Property] = prop
+		}
+	}
+	return props
+}
+
+// emptyProperties returns, for each of fields mapped and empty in values,
+// the JSON that clears its property on an update. properties leaves these
+// out, and go-notion can't encode them (its property values are omitempty).
+func (m Mapping) emptyProperties(fields []string, values map[string]string) map[string]json.RawMessage {
+	out := map[string]json.RawMessage{}
+	for _, field := range fields {
+		pm, ok := m[field]
+		if !ok || values[field] != "" {
+			continue
+		}
+		if v, ok := emptyProperty(pm.Type); ok {
+			out[pm.Property] = v
+		}
+	}
+	return out
+}
+
+func emptyProperty(typ string) (json.RawMessage, bool) {
+	switch typ {
+	case TypeRichText:
+		return json.RawMessage(`{"rich_text":[]}`), true
+	case TypeSelect:
+		return json.RawMessage(`{"select":null}`), true
+	case TypeMultiSelect:
+		return json.RawMessage(`{"multi_select":[]}`), true
+	case TypeURL:
+		return json.RawMessage(`{"url":null}`), true
+	case TypeDate:
+		return json.RawMessage(`{"date":null}`), true
+	case TypeNumber:
+		return json.RawMessage(`{"number":null}`), true
+	}
+	return nil, false // a page keeps its title
+}
+
+func encodeProperty(typ, v string) (gnt.DatabasePageProperty, bool) {
+	switch typ {
+	case TypeTitle:
+		return gnt.DatabasePageProperty{Title: rtChunks(v)}, true
+	case TypeRichText:
+		return gnt.DatabasePageProperty{RichText: rtChunks(v)}, true
+	case TypeSelect:
+		return gnt.DatabasePageProperty{Select: &gnt.SelectOptions{Name: v}}, true
+	case TypeMultiSelect:
+		var opts []gnt.SelectOptions
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				opts = append(opts, gnt.SelectOptions{Name: part})
+			}
+		}
+		return gnt.DatabasePageProperty{MultiSelect: opts}, len(opts) > 0
+	case TypeURL:
+		return gnt.DatabasePageProperty{URL: &v}, true
+	case TypeDate:
+		t, err := parseDateValue(v)
+		if err != nil {
+			return gnt.DatabasePageProperty{}, false
+		}
+		hasTime := len(v) > len(time.DateOnly)
+		return gnt.DatabasePageProperty{Date: &gnt.Date{Start: gnt.NewDateTime(t, hasTime)}}, true
+	case TypeNumber:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return gnt.DatabasePageProperty{}, false
+		}
+		return gnt.DatabasePageProperty{Number: &n}, true
+	}
+	return gnt.DatabasePageProperty{}, false
+}
+
+// values decodes the mapped fields of a page back to text; the reverse of
+// properties. Fields whose property is missing or empty are left out.
+func (m Mapping) values(props gnt.DatabasePageProperties, fields []string) map[string]string {
+	out := map[string]string{}
+	for _, field := range fields {
+		pm, ok := m[field]
+		if !ok {
+			continue
+		}
+		prop, ok := props[pm.Property]
+		if !ok {
+			continue
+		}
+		if v := decodeProperty(prop); v != "" {
+			out[field] = v
+		}
+	}
+	return out
+}
+
+// has reports whether field is mapped and its property is on the page, even
+// if empty: the difference values can't show between a cleared property and
+// one that isn't there.
+func (m Mapping) has(props gnt.DatabasePageProperties, field string) bool {
+	pm, ok := m[field]
+	if !ok {
+		return false
+	}
+	_, ok = props[pm.Property]
+	return ok
+}
+
+func decodeProperty(p gnt.DatabasePageProperty) string {
+	switch {
+	case p.Title != nil:
+		return plainText(p.Title)
+	case p.RichText != nil:
+		return plainText(p.RichText)
+	case p.Select != nil:
+		return p.Select.Name
+	case p.MultiSelect != nil:
+		names := make([]string, 0, len(p.MultiSelect))
+		for _, o := range p.MultiSelect {
+			names = append(names, o.Name)
+		}
+		return strings.Join(names, ", ")
+	case p.URL != nil:
+		return *p.URL
+	case p.Date != nil:
+		if p.Date.Start.HasTime() {
+			return p.Date.Start.Time.Format(time.RFC3339)
+		}
+		return p.Date.Start.Time.Format(time.DateOnly)
+	case p.Number != nil:
+		return strconv.FormatFloat(*p.Number, 'f', -1, 64)
+	}
+	return ""
+}
+
+// parseDateValue parses the text form produced by decodeProperty for dates.
+func parseDateValue(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, v)
+}
@@ -0,0 +1,102 @@
+package notion
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"jobflow.local/internal/domain"
+)
+
+func TestApplicationPropertiesRoundTrip(t *testing.T) {
+	m := DefaultMapping()
+	at := time.Date(2025, 11, 20, 20, 0, 0, 0, time.UTC)
+	app := domain.Application{
+		Stage:         "Interviewing",
+		Outcome:       "Pending",
+		Notes:         "Spoke to the recruiter",
+		InterviewTime: &at,
+	}
+
+	props := m.properties(applicationFields, applicationValues(app))
+	c := &Client{mapping: m}
+	got := c.applicationFromProperties(props)
+
+	// Notes aren't compared: rich text is read from plain_text, which only
+	// Notion fills in.
+	if got.Stage != app.Stage || got.Outcome != app.Outcome {
+		t.Errorf("round trip = %+v, want %+v", got, app)
+	}
+	if got.InterviewTime == nil || !got.InterviewTime.Equal(at) {
+		t.Errorf("interview time = %v, want %v", got.InterviewTime, at)
+	}
+}
+
+func TestEmptyPropertiesClearsEmptyFields(t *testing.T) {
+	m := DefaultMapping()
+	app := domain.Application{Stage: "Applied"} // outcome, notes and interview cleared
+
+	set := m.properties(applicationFields, applicationValues(app))
+	empty := m.emptyProperties(applicationFields, applicationValues(app))
+
+	if _, ok := set["Stage"]; !ok {
+		t.Errorf("Stage missing from set properties %v", set)
+	}
+	if _, ok := empty["Stage"]; ok {
+		t.Errorf("Stage should not be cleared")
+	}
+
+	want := map[string]string{
+		"Outcome":        `{"select":null}`,
+		"Notes":          `{"rich_text":[]}`,
+		"Next Interview": `{"date":null}`,
+	}
+	if len(empty) != len(want) {
+		t.Errorf("cleared %d properties, want %d: %s", len(empty), len(want), empty)
+	}
+	for name, js := range want {
+		if got := string(empty[name]); got != js {
+			t.Errorf("clear %s = %s, want %s", name, got, js)
+		}
+		if _, ok := set[name]; ok {
+			t.Errorf("%s is both set and cleared", name)
+		}
+	}
+}
+
+func TestEmptyPropertyEncodesForEveryClearableType(t *testing.T) {
+	for _, typ := range []string{TypeRichText, TypeSelect, TypeMultiSelect, TypeURL, TypeDate, TypeNumber} {
+		v, ok := emptyProperty(typ)
+		if !ok {
+			t.Errorf("%s: not clearable", typ)
+			continue
+		}
+		var obj map[string]any
+		if err := json.Unmarshal(v, &obj); err != nil || len(obj) != 1 {
+			t.Errorf("%s: clear value %s is not a single-key object", typ, v)
+		}
+		if _, ok := obj[typ]; !ok {
+			t.Errorf("%s: clear value %s doesn't set the %s key", typ, v, typ)
+		}
+	}
+	if _, ok := emptyProperty(TypeTitle); ok {
+		t.Errorf("titles should never be cleared")
+	}
+}
+
+func TestPropertiesSkipValuesThatDontFit(t *testing.T) {
+	m := Mapping{
+		FieldSalaryMin: {Property: "Salary Min", Type: TypeNumber},
+		FieldSalaryMax: {Property: "Salary Max", Type: TypeNumber},
+	}
+	props := m.properties([]string{FieldSalaryMin, FieldSalaryMax}, map[string]string{
+		FieldSalaryMin: "120000",
+		FieldSalaryMax: "$150k",
+	})
+	if p, ok := props["Salary Min"]; !ok || p.Number == nil || *p.Number != 120000 {
+		t.Errorf("Salary Min = %+v, want 120000", props["Salary Min"])
+	}
+	if _, ok := props["Salary Max"]; ok {
+		t.Errorf("Salary Max %q should have been skipped", "$150k")
+	}
+}
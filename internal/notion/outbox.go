@@ -0,0 +1,230 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	gnt "github.com/dstotijn/go-notion"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// Outbox retry policy: the wait doubles from outboxBaseDelay up to
+// outboxMaxDelay, and an item is dead-lettered after outboxMaxAttempts.
+const (
+	outboxBaseDelay   = 30 * time.Second
+	outboxMaxDelay    = time.Hour
+	outboxMaxAttempts = 10
+	outboxBatchSize   = 20
+)
+
+// Outbox delivers the Notion writes queued in SQLite by the store. Items
+// are claimed before delivery, so Run and DeliverApplication never send the
+// same item twice.
+type Outbox struct {
+	client   *Client
+	store    *store.Store
+	interval time.Duration
+	kick     chan struct{}
+}
+
+func NewOutbox(c *Client, st *store.Store, interval time.Duration) *Outbox {
+	return &Outbox{
+		client:   c,
+		store:    st,
+		interval: interval,
+		kick:     make(chan struct{}, 1),
+	}
+}
+
+// Kick wakes Run up without waiting for the next tick.
+func (o *Outbox) Kick() {
+	select {
+	case o.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers due items every interval (or when kicked) until ctx is done.
+func (o *Outbox) Run(ctx context.Context) {
+	if err := o.store.ResetStaleOutboxItems(ctx); err != nil {
+		log.Printf("[notion outbox] reset stale items: %v", err)
+	}
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		items, err := o.store.ListDueOutboxItems(ctx, outboxBatchSize)
+		if err != nil {
+			log.Printf("[notion outbox] list due items: %v", err)
+		}
+		for _, it := range items {
+			o.deliver(ctx, it)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.kick:
+		}
+	}
+}
+
+// DeliverApplication tries the pending items of one application right away,
+// ignoring their backoff. Failures stay queued for Run; the returned error
+// is the last delivery error, for the caller to report.
+func (o *Outbox) DeliverApplication(ctx context.Context, appID int64) error {
+	items, err := o.store.ListPendingOutboxItemsForApplication(ctx, appID)
+	if err != nil {
+		return err
+	}
+
+	var last error
+	for _, it := range items {
+		if err := o.deliver(ctx, it); err != nil {
+			last = err
+		}
+	}
+	return last
+}
+
+// deliver claims one item, sends it and records the outcome.
+func (o *Outbox) deliver(ctx context.Context, it store.OutboxItem) error {
+	claimed, err := o.store.ClaimOutboxItem(ctx, it.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	err = o.send(ctx, it)
+	if err == nil {
+		if err := o.store.CompleteOutboxItem(ctx, it.ID); err != nil {
+			log.Printf("[notion outbox] complete item %d: %v", it.ID, err)
+		}
+		return nil
+	}
+
+	attempts := it.Attempts + 1
+	var retryAt *time.Time
+	if !permanent(err) && attempts < outboxMaxAttempts {
+		t := time.Now().Add(outboxDelay(attempts))
+		retryAt = &t
+	}
+
+	// The request context may be the one that just expired; record the
+	// failure regardless.
+	if ferr := o.store.FailOutboxItem(context.WithoutCancel(ctx), it.ID, err.Error(), retryAt); ferr != nil {
+		log.Printf("[notion outbox] record failure of item %d: %v", it.ID, ferr)
+	}
+	if retryAt == nil {
+		log.Printf("[notion outbox] item %d (%s, application %d) dead after %d attempts: %v", it.ID, it.Op, it.ApplicationID, attempts, err)
+	} else {
+		log.Printf("[notion outbox] item %d (%s, application %d) failed, retry at %s: %v", it.ID, it.Op, it.ApplicationID, retryAt.Format(time.RFC3339), err)
+	}
+	return err
+}
+
+func (o *Outbox) send(ctx context.Context, it store.OutboxItem) error {
+	app, err := o.store.GetApplication(ctx, it.ApplicationID)
+	if err != nil {
+		return fmt.Errorf("load application: %w", err)
+	}
+
+	switch it.Op {
+	case store.OutboxOpPage:
+		job, err := o.store.GetJob(ctx, app.JobID)
+		if err != nil {
+			return fmt.Errorf("load job: %w", err)
+		}
+		pageID, err := o.pushJobPage(ctx, job, app, it.Enrichment)
+		if err != nil {
+			return err
+		}
+		return o.store.SaveNotionPageID(ctx, app.ID, pageID)
+
+	case store.OutboxOpApplication:
+		if app.NotionPageID == nil {
+			// The page doesn't exist yet; its pending create carries the
+			// latest values anyway.
+			return nil
+		}
+		return o.client.UpdateApplicationPage(ctx, *app.NotionPageID, app)
+
+	case store.OutboxOpEnrichment:
+		if it.Enrichment == nil {
+			return nil
+		}
+		if app.NotionPageID == nil {
+			// Retried with backoff until the page op has created the page.
+			return errors.New("notion page not created yet")
+		}
+		// A salary inferred during enrichment isn't on the page yet.
+		job, err := o.store.GetJob(ctx, app.JobID)
+		if err != nil {
+			return fmt.Errorf("load job: %w", err)
+		}
+		if job.SalaryRange != nil && job.SalaryRange.Inferred {
+			if err := o.client.UpdateSalary(ctx, *app.NotionPageID, job); err != nil {
+				return err
+			}
+		}
+		return o.client.AppendEnrichment(ctx, *app.NotionPageID, *it.Enrichment)
+	}
+	return fmt.Errorf("unknown outbox op %q", it.Op)
+}
+
+// pushJobPage updates the Notion page already linked to this job, or creates
+// one if there is none (or the old one was deleted). The page body (enr,
+// description) is only written on create.
+func (o *Outbox) pushJobPage(ctx context.Context, job domain.Job, app domain.Application, enr *domain.Enrichment) (string, error) {
+	pageID := ""
+	if app.NotionPageID != nil {
+		pageID = *app.NotionPageID
+	} else {
+		var err error
+		pageID, err = o.store.FindNotionPageIDForJob(ctx, job.ID)
+		if err != nil {
+			return "", fmt.Errorf("look up existing page: %w", err)
+		}
+	}
+
+	if pageID != "" {
+		err := o.client.UpdateJobPage(ctx, pageID, job, app)
+		if err == nil {
+			return pageID, nil
+		}
+		if !IsNotFound(err) {
+			return "", fmt.Errorf("UpdateJobPage %s: %w", pageID, err)
+		}
+		log.Printf("[notion outbox] page %s no longer exists, creating a new one", pageID)
+	}
+
+	pageID, err := o.client.CreateJobPage(ctx, job, app, enr)
+	if err != nil {
+		if pageID == "" {
+			return "", fmt.Errorf("CreateJobPage: %w", err)
+		}
+		// The row exists, only part of its body is missing; keep the link.
+		log.Printf("[notion outbox] warning: CreateJobPage %s: %v", pageID, err)
+	}
+	return pageID, nil
+}
+
+// outboxDelay is the wait before attempt number attempts+1.
+func outboxDelay(attempts int) time.Duration {
+	return min(outboxBaseDelay<<(attempts-1), outboxMaxDelay)
+}
+
+// permanent reports errors that retrying can't fix: Notion rejecting the
+// request itself, or the application being gone.
+func permanent(err error) bool {
+	return errors.Is(err, gnt.ErrValidation) ||
+		errors.Is(err, gnt.ErrInvalidRequest) ||
+		errors.Is(err, gnt.ErrInvalidJSON) ||
+		errors.Is(err, store.ErrNotFound)
+}
@@ -0,0 +1,241 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gnt "github.com/dstotijn/go-notion"
+
+	"jobflow.local/internal/store"
+)
+
+// Reconciler finds drift between SQLite and the tracker database and,
+// optionally, fixes it.
+type Reconciler struct {
+	client *Client
+	store  *store.Store
+	outbox *Outbox
+}
+
+// TrackerPage is a row of the tracker database.
+type TrackerPage struct {
+	ID      string
+	Title   string
+	Company string
+	URL     string
+}
+
+// StalePage is a page applications still point to that is no longer a live
+// row of the tracker.
+type StalePage struct {
+	PageID         string
+	ApplicationIDs []int64
+}
+
+// ReconcileReport lists every kind of drift Plan looks for.
+type ReconcileReport struct {
+	Unlinked []store.ApplicationListItem // applications without a page
+	Orphans  []TrackerPage               // live pages no application points to
+	Archived []StalePage                 // linked pages archived in Notion
+	Missing  []StalePage                 // linked pages deleted or moved out of the tracker
+}
+
+func (r ReconcileReport) OK() bool {
+	return len(r.Unlinked) == 0 && len(r.Orphans) == 0 && len(r.Archived) == 0 && len(r.Missing) == 0
+}
+
+// ReconcileResult counts what Apply changed.
+type ReconcileResult struct {
+	Linked   int // applications matched to an orphan page by job URL
+	Created  int // pages created for applications
+	Queued   int // page creations left in the outbox after a failure
+	Unlinked int // applications unlinked from a missing page
+	Restored int // archived pages restored
+}
+
+func NewReconciler(c *Client, st *store.Store, ob *Outbox) *Reconciler {
+	return &Reconciler{
+		client: c,
+		store:  st,
+		outbox: ob,
+	}
+}
+
+// Plan compares every page of the tracker with the applications in SQLite.
+// It only reads.
+func (r *Reconciler) Plan(ctx context.Context) (ReconcileReport, error) {
+	var report ReconcileReport
+
+	pages, err := r.trackerPages(ctx)
+	if err != nil {
+		return report, err
+	}
+	links, err := r.store.ListNotionLinks(ctx)
+	if err != nil {
+		return report, fmt.Errorf("list linked applications: %w", err)
+	}
+	report.Unlinked, err = r.store.ListUnlinkedApplications(ctx)
+	if err != nil {
+		return report, fmt.Errorf("list unlinked applications: %w", err)
+	}
+
+	live := map[string]bool{}
+	for _, p := range pages {
+		live[pageKey(p.ID)] = true
+	}
+
+	// Group links by page, keeping the order in which pages first appear.
+	var stale []StalePage
+	linked := map[string]bool{}
+	staleIdx := map[string]int{}
+	for _, l := range links {
+		key := pageKey(l.PageID)
+		linked[key] = true
+		if live[key] {
+			continue
+		}
+		i, ok := staleIdx[key]
+		if !ok {
+			i = len(stale)
+			staleIdx[key] = i
+			stale = append(stale, StalePage{PageID: l.PageID})
+		}
+		stale[i].ApplicationIDs = append(stale[i].ApplicationIDs, l.ApplicationID)
+	}
+
+	for _, p := range pages {
+		if !linked[pageKey(p.ID)] {
+			report.Orphans = append(report.Orphans, p)
+		}
+	}
+
+	// Queries leave archived pages out, so look each remaining one up.
+	for _, sp := range stale {
+		page, err := r.client.api.FindPageByID(ctx, sp.PageID)
+		switch {
+		case IsNotFound(err):
+			report.Missing = append(report.Missing, sp)
+		case err != nil:
+			return report, fmt.Errorf("retrieve page %s: %w", sp.PageID, err)
+		case page.Archived:
+			report.Archived = append(report.Archived, sp)
+		default:
+			// The page exists but was moved out of the tracker.
+			report.Missing = append(report.Missing, sp)
+		}
+	}
+
+	return report, nil
+}
+
+// Apply fixes what Plan found:
+//   - applications linked to a missing page are unlinked and get a new page;
+//   - an unlinked application whose job URL matches an orphan page is linked
+//     to it instead of getting a duplicate;
+//   - the remaining unlinked applications get a page through the outbox;
+//   - archived pages are restored only when restoreArchived is set.
+//
+// Orphans that match nothing are left alone.
+func (r *Reconciler) Apply(ctx context.Context, report ReconcileReport, restoreArchived bool) (ReconcileResult, error) {
+	var res ReconcileResult
+
+	unlinked := report.Unlinked
+	for _, sp := range report.Missing {
+		n, err := r.store.ClearNotionPageID(ctx, sp.PageID)
+		if err != nil {
+			return res, fmt.Errorf("unlink page %s: %w", sp.PageID, err)
+		}
+		res.Unlinked += int(n)
+	}
+	if len(report.Missing) > 0 {
+		var err error
+		unlinked, err = r.store.ListUnlinkedApplications(ctx)
+		if err != nil {
+			return res, fmt.Errorf("list unlinked applications: %w", err)
+		}
+	}
+
+	orphanByURL := map[string]string{}
+	for _, p := range report.Orphans {
+		if u := normalizeURL(p.URL); u != "" {
+			orphanByURL[u] = p.ID
+		}
+	}
+
+	for _, it := range unlinked {
+		if pageID, ok := orphanByURL[normalizeURL(it.Job.URL)]; ok {
+			if err := r.store.SaveNotionPageID(ctx, it.Application.ID, pageID); err != nil {
+				return res, fmt.Errorf("link application %d: %w", it.Application.ID, err)
+			}
+			res.Linked++
+			continue
+		}
+
+		if _, err := r.store.EnqueueNotionPage(ctx, it.Application.ID); err != nil {
+			return res, fmt.Errorf("queue page for application %d: %w", it.Application.ID, err)
+		}
+		if err := r.outbox.DeliverApplication(ctx, it.Application.ID); err != nil {
+			res.Queued++
+			continue
+		}
+		res.Created++
+	}
+
+	if restoreArchived {
+		archived := false
+		for _, sp := range report.Archived {
+			_, err := r.client.api.UpdatePage(ctx, sp.PageID, gnt.UpdatePageParams{Archived: &archived})
+			if err != nil {
+				return res, fmt.Errorf("restore page %s: %w", sp.PageID, err)
+			}
+			res.Restored++
+		}
+	}
+
+	return res, nil
+}
+
+// trackerPages queries every live page of the tracker database.
+func (r *Reconciler) trackerPages(ctx context.Context) ([]TrackerPage, error) {
+	fields := []string{FieldTitle, FieldCompany, FieldURL}
+	query := &gnt.DatabaseQuery{PageSize: 100}
+
+	var pages []TrackerPage
+	for {
+		resp, err := r.client.api.QueryDatabase(ctx, r.client.databaseID, query)
+		if err != nil {
+			return nil, fmt.Errorf("query database: %w", err)
+		}
+
+		for _, page := range resp.Results {
+			p := TrackerPage{ID: page.ID}
+			if props, ok := page.Properties.(gnt.DatabasePageProperties); ok {
+				v := r.client.mapping.values(props, fields)
+				p.Title, p.Company, p.URL = v[FieldTitle], v[FieldCompany], v[FieldURL]
+			}
+			pages = append(pages, p)
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			break
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+	return pages, nil
+}
+
+// pageKey makes dashed and undashed forms of a page id compare equal.
+func pageKey(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
+
+// normalizeURL drops the query string, fragment and trailing slash, which
+// LinkedIn varies between visits to the same posting.
+func normalizeURL(u string) string {
+	u = strings.TrimSpace(u)
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	return strings.ToLower(strings.TrimSuffix(u, "/"))
+}
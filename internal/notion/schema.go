@@ -0,0 +1,200 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	gnt "github.com/dstotijn/go-notion"
+
+	"jobflow.local/internal/domain"
+)
+
+// defaultSelectOptions are the values JobFlow itself writes (see the
+// extension popup), so they're worth provisioning up front.
+var defaultSelectOptions = map[string][]string{
+	FieldWorkMode: {domain.WorkModeRemote, domain.WorkModeHybrid, domain.WorkModeOnSite},
+	FieldStage:    {"Saved", "Applied", "Recruiter screen", "Round 1 interview", "Final interview"},
+	FieldOutcome:  {"Active"},
+}
+
+// SchemaIssue is one mapped property the tracker database doesn't match.
+type SchemaIssue struct {
+	Field    string
+	Property string
+	Want     string   // type from the mapping
+	Got      string   // type in Notion, "" when the property is missing
+	Options  []string // select options JobFlow writes that don't exist yet
+}
+
+func (i SchemaIssue) String() string {
+	switch {
+	case i.Got == "":
+		return fmt.Sprintf("%q (%s): missing, want %s", i.Property, i.Field, i.Want)
+	case i.Got != i.Want:
+		return fmt.Sprintf("%q (%s): is %s, want %s", i.Property, i.Field, i.Got, i.Want)
+	default:
+		return fmt.Sprintf("%q (%s): missing options %s", i.Property, i.Field, strings.Join(i.Options, ", "))
+	}
+}
+
+// SchemaReport lists every mismatch between the mapping and the database.
+type SchemaReport struct {
+	Issues []SchemaIssue
+}
+
+func (r SchemaReport) OK() bool { return len(r.Issues) == 0 }
+
+// CheckSchema retrieves the tracker database and compares its properties
+// with the ones CreateJobPage writes. It also records which optional
+// properties exist; until it has run, none are written.
+func (c *Client) CheckSchema(ctx context.Context) (SchemaReport, error) {
+	db, err := c.api.FindDatabaseByID(ctx, c.databaseID)
+	if err != nil {
+		return SchemaReport{}, err
+	}
+	return c.compareSchema(db.Properties), nil
+}
+
+func (c *Client) compareSchema(props gnt.DatabaseProperties) SchemaReport {
+	fields := make([]string, 0, len(c.mapping))
+	for field := range c.mapping {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var report SchemaReport
+	present := map[string]bool{}
+	for _, field := range fields {
+		pm := c.mapping[field]
+		issue := SchemaIssue{Field: field, Property: pm.Property, Want: pm.Type}
+
+		prop, ok := props[pm.Property]
+		if !ok {
+			if optionalFields[field] {
+				continue
+			}
+			issue.Options = defaultSelectOptions[field]
+			report.Issues = append(report.Issues, issue)
+			continue
+		}
+
+		issue.Got = string(prop.Type)
+		if issue.Got != pm.Type {
+			report.Issues = append(report.Issues, issue)
+			continue
+		}
+		if optionalFields[field] {
+			present[field] = true
+		}
+
+		if issue.Options = missingOptions(prop, defaultSelectOptions[field]); len(issue.Options) > 0 {
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	c.mu.Lock()
+	c.optionalPresent = present
+	c.mu.Unlock()
+	return report
+}
+
+func selectMetadata(prop gnt.DatabaseProperty) *gnt.SelectMetadata {
+	switch {
+	case prop.Select != nil:
+		return prop.Select
+	case prop.MultiSelect != nil:
+		return prop.MultiSelect
+	}
+	return nil
+}
+
+func missingOptions(prop gnt.DatabaseProperty, want []string) []string {
+	meta := selectMetadata(prop)
+	if meta == nil {
+		return nil
+	}
+
+	have := map[string]bool{}
+	for _, o := range meta.Options {
+		have[o.Name] = true
+	}
+
+	var missing []string
+	for _, name := range want {
+		if !have[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// ProvisionSchema adds the missing properties and select options from a
+// CheckSchema report. It never changes the type of an existing property and
+// can't add a second title, so those issues are returned as unfixed.
+func (c *Client) ProvisionSchema(ctx context.Context, report SchemaReport) (unfixed []SchemaIssue, err error) {
+	db, err := c.api.FindDatabaseByID(ctx, c.databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]*gnt.DatabaseProperty{}
+	for _, issue := range report.Issues {
+		switch {
+		case issue.Got == "" && issue.Want != TypeTitle:
+			updates[issue.Property] = newDatabaseProperty(issue.Want, issue.Options)
+
+		case issue.Got == issue.Want && len(issue.Options) > 0:
+			existing := db.Properties[issue.Property]
+			meta := selectMetadata(existing)
+			if meta == nil {
+				unfixed = append(unfixed, issue)
+				continue
+			}
+			// Notion replaces the option list, so resend the current options.
+			var names []string
+			for _, o := range meta.Options {
+				names = append(names, o.Name)
+			}
+			updates[issue.Property] = newDatabaseProperty(issue.Want, append(names, issue.Options...))
+
+		default:
+			unfixed = append(unfixed, issue)
+		}
+	}
+
+	if len(updates) == 0 {
+		return unfixed, nil
+	}
+	_, err = c.api.UpdateDatabase(ctx, c.databaseID, gnt.UpdateDatabaseParams{
+		Properties: updates,
+	})
+	return unfixed, err
+}
+
+// newDatabaseProperty builds the definition of a property of the given type.
+func newDatabaseProperty(typ string, options []string) *gnt.DatabaseProperty {
+	prop := &gnt.DatabaseProperty{Type: gnt.DatabasePropertyType(typ)}
+
+	opts := []gnt.SelectOptions{}
+	for _, name := range options {
+		opts = append(opts, gnt.SelectOptions{Name: name})
+	}
+
+	switch typ {
+	case TypeRichText:
+		prop.RichText = &gnt.EmptyMetadata{}
+	case TypeURL:
+		prop.URL = &gnt.EmptyMetadata{}
+	case TypeDate:
+		prop.Date = &gnt.EmptyMetadata{}
+	case TypeNumber:
+		prop.Number = &gnt.NumberMetadata{Format: gnt.NumberFormatNumber}
+	case TypeSelect:
+		prop.Select = &gnt.SelectMetadata{Options: opts}
+	case TypeMultiSelect:
+		prop.MultiSelect = &gnt.SelectMetadata{Options: opts}
+	}
+	return prop
+}
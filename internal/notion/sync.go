@@ -0,0 +1,216 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	gnt "github.com/dstotijn/go-notion"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// syncCursorKey is the sync_state key holding the last_edited_time of the
+// newest page already pulled.
+const syncCursorKey = "notion.pull.last_edited_time"
+
+// Syncer pulls edits made in the Notion tracker (Stage, Outcome, Next
+// Interview) back into SQLite on a fixed interval.
+type Syncer struct {
+	client   *Client
+	store    *store.Store
+	interval time.Duration
+}
+
+// SyncResult summarizes one pass of the Syncer.
+type SyncResult struct {
+	Pages     int // pages returned by Notion
+	Updated   int // applications changed in SQLite
+	Unmatched int // pages with no application pointing to them
+}
+
+func NewSyncer(c *Client, st *store.Store, interval time.Duration) *Syncer {
+	return &Syncer{
+		client:   c,
+		store:    st,
+		interval: interval,
+	}
+}
+
+// Run syncs once immediately and then every interval until ctx is done.
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := s.SyncOnce(ctx)
+		if err != nil {
+			log.Printf("[notion sync] error: %v", err)
+		} else if res.Updated > 0 {
+			log.Printf("[notion sync] %d pages seen, %d applications updated", res.Pages, res.Updated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncOnce queries every page edited since the saved cursor and applies the
+// changes to the matching application. The cursor only moves forward after
+// all pages were handled, so a failed pass is retried in full.
+func (s *Syncer) SyncOnce(ctx context.Context) (SyncResult, error) {
+	var res SyncResult
+
+	since, err := s.cursor(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	query := &gnt.DatabaseQuery{
+		Sorts: []gnt.DatabaseQuerySort{
+			{Timestamp: gnt.SortTimeStampLastEditedTime, Direction: gnt.SortDirAsc},
+		},
+		PageSize: 100,
+	}
+	if !since.IsZero() {
+		// Notion rounds last_edited_time to the minute, so re-read the
+		// boundary minute; applying a page twice is harmless.
+		query.Filter = &gnt.DatabaseQueryFilter{
+			Timestamp: gnt.TimestampLastEditedTime,
+			DatabaseQueryPropertyFilter: gnt.DatabaseQueryPropertyFilter{
+				LastEditedTime: &gnt.DatePropertyFilter{OnOrAfter: &since},
+			},
+		}
+	}
+
+	newest := since
+	for {
+		resp, err := s.client.api.QueryDatabase(ctx, s.client.databaseID, query)
+		if err != nil {
+			return res, fmt.Errorf("query database: %w", err)
+		}
+
+		for _, page := range resp.Results {
+			res.Pages++
+			changed, err := s.applyPage(ctx, page)
+			if errors.Is(err, store.ErrNotFound) {
+				res.Unmatched++
+			} else if err != nil {
+				return res, fmt.Errorf("page %s: %w", page.ID, err)
+			}
+			if changed {
+				res.Updated++
+			}
+			if page.LastEditedTime.After(newest) {
+				newest = page.LastEditedTime
+			}
+		}
+
+		if !resp.HasMore || resp.NextCursor == nil {
+			break
+		}
+		query.StartCursor = *resp.NextCursor
+	}
+
+	if newest.After(since) {
+		if err := s.store.SetSyncState(ctx, syncCursorKey, newest.UTC().Format(time.RFC3339)); err != nil {
+			return res, fmt.Errorf("save sync cursor: %w", err)
+		}
+	}
+	return res, nil
+}
+
+func (s *Syncer) cursor(ctx context.Context) (time.Time, error) {
+	v, err := s.store.GetSyncState(ctx, syncCursorKey)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("load sync cursor: %w", err)
+	}
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse sync cursor %q: %w", v, err)
+	}
+	return t, nil
+}
+
+// applyPage copies Stage, Outcome and Next Interview from a page onto every
+// application linked to it, skipping those with writes queued for Notion.
+// Notes are not pulled back: the local copy holds the full text and Notion's
+// may be truncated.
+func (s *Syncer) applyPage(ctx context.Context, page gnt.Page) (bool, error) {
+	props, ok := page.Properties.(gnt.DatabasePageProperties)
+	if !ok {
+		return false, nil
+	}
+
+	apps, err := s.store.ListApplicationsByNotionPageID(ctx, page.ID)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for _, app := range apps {
+		updated, err := s.applyProperties(ctx, page.ID, props, app)
+		if err != nil {
+			return changed, fmt.Errorf("application %d: %w", app.ID, err)
+		}
+		changed = changed || updated
+	}
+	return changed, nil
+}
+
+// applyProperties updates app from the properties of its page, unless it
+// has writes queued for Notion.
+func (s *Syncer) applyProperties(ctx context.Context, pageID string, props gnt.DatabasePageProperties, app domain.Application) (bool, error) {
+	// A local change still in the outbox is newer than the page; taking the
+	// page's value now would undo it before it is delivered.
+	queued, err := s.store.HasQueuedNotionWrite(ctx, app.ID, store.OutboxOpApplication, store.OutboxOpPage)
+	if err != nil {
+		return false, err
+	}
+	if queued {
+		return false, nil
+	}
+
+	// Only fields that are mapped and on the page are compared: a property
+	// the mapping leaves out, or the page doesn't have, says nothing about
+	// the local value. One that is there but empty was cleared in Notion.
+	m := s.client.mapping
+	v := m.values(props, applicationFields)
+
+	var patch store.ApplicationPatch
+	if m.has(props, FieldStage) && v[FieldStage] != app.Stage {
+		stage := v[FieldStage]
+		patch.Stage = &stage
+	}
+	if m.has(props, FieldOutcome) && v[FieldOutcome] != app.Outcome {
+		outcome := v[FieldOutcome]
+		patch.Outcome = &outcome
+	}
+	if m.has(props, FieldNextInterview) {
+		raw, set := v[FieldNextInterview]
+		if !set {
+			patch.ClearInterviewTime = app.InterviewTime != nil
+		} else if t, err := parseDateValue(raw); err != nil {
+			log.Printf("[notion sync] page %s: ignoring Next Interview %q: %v", pageID, raw, err)
+		} else if app.InterviewTime == nil || !t.Equal(*app.InterviewTime) {
+			patch.InterviewTime = &t
+		}
+	}
+	if patch == (store.ApplicationPatch{}) {
+		return false, nil
+	}
+
+	if _, err := s.store.UpdateApplication(ctx, app.ID, patch, domain.SourceNotion); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,153 @@
+package notion
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gnt "github.com/dstotijn/go-notion"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/store"
+)
+
+// newTestStore opens a migrated store on a fresh database file.
+func newTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	db, err := store.OpenSQLite(filepath.Join(t.TempDir(), "jobflow.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	st := store.New(db)
+	if err := st.Migrate(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return st
+}
+
+// saveLinkedApplication saves app with a new job and links it to pageID, as
+// if the outbox had delivered it.
+func saveLinkedApplication(t *testing.T, st *store.Store, app domain.Application, pageID string) domain.Application {
+	t.Helper()
+	ctx := context.Background()
+	job := domain.Job{ExternalID: pageID, Title: "Go Engineer", Company: "Acme"}
+	if err := st.UpsertJobAndApplication(ctx, &job, &app, nil, domain.SourceAPI); err != nil {
+		t.Fatalf("save job: %v", err)
+	}
+	if err := st.SaveNotionPageID(ctx, app.ID, pageID); err != nil {
+		t.Fatalf("link page: %v", err)
+	}
+	items, err := st.ListOutboxItems(ctx, store.OutboxPending, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, it := range items {
+		if err := st.CompleteOutboxItem(ctx, it.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return app
+}
+
+func TestApplyPageLeavesUnmappedFieldsAlone(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	at := time.Date(2025, 11, 20, 20, 0, 0, 0, time.UTC)
+	app := saveLinkedApplication(t, st, domain.Application{
+		Stage:         "Applied",
+		Outcome:       "Pending",
+		InterviewTime: &at,
+	}, "page-1")
+
+	m := DefaultMapping()
+	delete(m, FieldOutcome)
+	delete(m, FieldNextInterview)
+	s := NewSyncer(&Client{mapping: m}, st, 0)
+
+	props := m.properties(applicationFields, applicationValues(domain.Application{Stage: "Interviewing"}))
+	updated, err := s.applyPage(ctx, gnt.Page{ID: "page-1", Properties: props})
+	if err != nil {
+		t.Fatalf("apply page: %v", err)
+	}
+	if !updated {
+		t.Fatal("stage change not applied")
+	}
+
+	got, err := st.GetApplication(ctx, app.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Stage != "Interviewing" {
+		t.Errorf("stage = %q, want Interviewing", got.Stage)
+	}
+	if got.Outcome != "Pending" {
+		t.Errorf("outcome = %q, want the local Pending kept", got.Outcome)
+	}
+	if got.InterviewTime == nil || !got.InterviewTime.Equal(at) {
+		t.Errorf("interview time = %v, want the local %v kept", got.InterviewTime, at)
+	}
+}
+
+func TestApplyPageSkipsMissingAndTakesClearedProperties(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	at := time.Date(2025, 11, 20, 20, 0, 0, 0, time.UTC)
+	app := saveLinkedApplication(t, st, domain.Application{
+		Stage:         "Applied",
+		Outcome:       "Pending",
+		InterviewTime: &at,
+	}, "page-1")
+
+	m := DefaultMapping()
+	s := NewSyncer(&Client{mapping: m}, st, 0)
+
+	// The page has Stage and an empty Next Interview, and no Outcome at all.
+	props := m.properties(applicationFields, applicationValues(domain.Application{Stage: "Applied"}))
+	props[m[FieldNextInterview].Property] = gnt.DatabasePageProperty{Type: gnt.DBPropTypeDate}
+	delete(props, m[FieldOutcome].Property)
+
+	if _, err := s.applyPage(ctx, gnt.Page{ID: "page-1", Properties: props}); err != nil {
+		t.Fatalf("apply page: %v", err)
+	}
+
+	got, err := st.GetApplication(ctx, app.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Outcome != "Pending" {
+		t.Errorf("outcome = %q, want Pending kept while the page has no Outcome", got.Outcome)
+	}
+	if got.InterviewTime != nil {
+		t.Errorf("interview time = %v, want it cleared like the page's", got.InterviewTime)
+	}
+}
+
+func TestApplyPageUpdatesEveryLinkedApplication(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	first := saveLinkedApplication(t, st, domain.Application{Stage: "Applied"}, "page-1")
+	again := saveLinkedApplication(t, st, domain.Application{Stage: "Applied"}, "page-1")
+	if first.ID == again.ID {
+		t.Fatalf("re-applying reused application %d", first.ID)
+	}
+
+	m := DefaultMapping()
+	s := NewSyncer(&Client{mapping: m}, st, 0)
+	props := m.properties(applicationFields, applicationValues(domain.Application{Stage: "Interviewing"}))
+	if _, err := s.applyPage(ctx, gnt.Page{ID: "page-1", Properties: props}); err != nil {
+		t.Fatalf("apply page: %v", err)
+	}
+
+	for _, id := range []int64{first.ID, again.ID} {
+		got, err := st.GetApplication(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Stage != "Interviewing" {
+			t.Errorf("application %d stage = %q, want Interviewing", id, got.Stage)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package notion
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Retry and rate-limit settings. Notion allows an average of three requests
+// per second per integration.
+const (
+	requestsPerSecond = 3
+	maxRetries        = 4
+	baseBackoff       = 500 * time.Millisecond
+	maxBackoff        = 30 * time.Second
+)
+
+// Stats counts what the transport did since the client was created.
+type Stats struct {
+	Requests  int64 `json:"requests"`   // attempts sent to Notion, retries included
+	Retries   int64 `json:"retries"`    // attempts that were a retry
+	Throttled int64 `json:"throttled"`  // 429 responses
+	Waits     int64 `json:"rate_waits"` // times the local limiter delayed a request
+}
+
+// transport spaces requests to stay under the per-second budget and retries
+// rate-limited and transient failures with exponential backoff and jitter.
+// One transport is shared by every call made through a Client, so the
+// budget holds across concurrent requests.
+type transport struct {
+	base    http.RoundTripper
+	limiter *limiter
+
+	requests  atomic.Int64
+	retries   atomic.Int64
+	throttled atomic.Int64
+}
+
+func newTransport(base http.RoundTripper) *transport {
+	return &transport{
+		base:    base,
+		limiter: &limiter{interval: time.Second / requestsPerSecond},
+	}
+}
+
+func (t *transport) stats() Stats {
+	return Stats{
+		Requests:  t.requests.Load(),
+		Retries:   t.retries.Load(),
+		Throttled: t.throttled.Load(),
+		Waits:     t.limiter.waits.Load(),
+	}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		try := req
+		if attempt > 0 {
+			t.retries.Add(1)
+			try = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				try.Body = body
+			}
+		}
+
+		if err := t.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		t.requests.Add(1)
+
+		resp, err := t.base.RoundTrip(try)
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			t.throttled.Add(1)
+		}
+
+		retry, delay := t.shouldRetry(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry decides whether an attempt is retried and how long to wait.
+// 429 and 503 mean Notion didn't process the request, so they are always
+// retried. Other 5xx and network errors are only retried for requests that
+// are safe to repeat: creating a page twice would duplicate the row.
+func (t *transport) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+	if attempt >= maxRetries {
+		return false, 0
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return false, 0
+	}
+
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false, 0
+		}
+		return idempotent(req), backoff(attempt)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		delay := backoff(attempt)
+		if ra, ok := retryAfter(resp); ok {
+			delay = ra + jitter(ra/10)
+		}
+		// Hold back every other request too, not just this one.
+		t.limiter.pause(delay)
+		return true, delay
+	case http.StatusServiceUnavailable:
+		if ra, ok := retryAfter(resp); ok {
+			return true, ra + jitter(ra/10)
+		}
+		return true, backoff(attempt)
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(req), backoff(attempt)
+	}
+	return false, 0
+}
+
+// idempotent reports whether repeating req can't create duplicates. Notion
+// uses POST for database queries and search, which only read.
+func idempotent(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return true
+	}
+	return strings.HasSuffix(req.URL.Path, "/query") || strings.HasSuffix(req.URL.Path, "/search")
+}
+
+// retryAfter parses a Retry-After header given in seconds.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	secs, err := strconv.ParseFloat(v, 64)
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return min(time.Duration(secs*float64(time.Second)), maxBackoff), true
+}
+
+// backoff is exponential with full jitter: a random wait in
+// [0, min(maxBackoff, baseBackoff * 2^attempt)].
+func backoff(attempt int) time.Duration {
+	ceiling := min(baseBackoff<<attempt, maxBackoff)
+	return jitter(ceiling)
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// limiter hands out evenly spaced send slots shared by all goroutines.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+
+	waits atomic.Int64
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	d := slot.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+	l.waits.Add(1)
+	return sleep(ctx, d)
+}
+
+// pause pushes the next free slot at least d into the future.
+func (l *limiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
@@ -0,0 +1,246 @@
+// Package salary turns free-text salaries such as "$20–$25/hour" or "$100k"
+// into a domain.SalaryRange.
+package salary
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"jobflow.local/internal/domain"
+)
+
+// DefaultHoursPerYear is a full-time year: 52 weeks of 40 hours.
+const DefaultHoursPerYear = 2080
+
+// Currency markers, checked in order so "CA$" wins over "$".
+var currencies = []struct {
+	marker string
+	code   string
+}{
+	{"us$", "USD"}, {"ca$", "CAD"}, {"c$", "CAD"}, {"a$", "AUD"}, {"au$", "AUD"},
+	{"nz$", "NZD"}, {"s$", "SGD"}, {"hk$", "HKD"}, {"r$", "BRL"},
+	{"$", "USD"}, {"€", "EUR"}, {"£", "GBP"}, {"¥", "JPY"}, {"₹", "INR"},
+}
+
+// currencyCodePattern matches the ISO codes recognized when written out.
+var currencyCodePattern = regexp.MustCompile(`(?i)\b(USD|CAD|AUD|NZD|SGD|HKD|BRL|EUR|GBP|JPY|INR|CHF|SEK|NOK|DKK|PLN|MXN|ZAR)\b`)
+
+// Period markers, most specific first.
+var periods = []struct {
+	pattern *regexp.Regexp
+	period  string
+}{
+	{regexp.MustCompile(`(?i)(/\s*h(ou)?r\b|per\s+h(ou)?r\b|hourly|an\s+hour|\bp/?h\b)`), domain.SalaryPerHour},
+	{regexp.MustCompile(`(?i)(/\s*day\b|per\s+day\b|daily|a\s+day\b|\bp/?d\b)`), domain.SalaryPerDay},
+	{regexp.MustCompile(`(?i)(/\s*w(ee)?k\b|per\s+week\b|weekly|a\s+week\b|\bp/?w\b)`), domain.SalaryPerWeek},
+	{regexp.MustCompile(`(?i)(/\s*mo(nth)?\b|per\s+month\b|monthly|a\s+month\b)`), domain.SalaryPerMonth},
+	{regexp.MustCompile(`(?i)(/\s*y(ea)?r\b|per\s+(year|annum)\b|annual(ly)?|yearly|a\s+year\b|\bp/?a\b|\bpa\b)`), domain.SalaryPerYear},
+}
+
+// amountPattern matches "100,000", "100.000", "100 000", "120,000.00",
+// "95.5", "120k", "1.2M".
+var amountPattern = regexp.MustCompile(`(\d{1,3}(?:[,.\s]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d+)?)\s*([kKmM])?\b`)
+
+// rangeSeparator is what joins the two ends of a range once currency and
+// period markers are taken out: "20–25", "$20 - $25", "100k to 120k".
+var rangeSeparator = regexp.MustCompile(`(?i)^\s*(-|–|—|to)\s*$`)
+
+// Parse reads a salary like "$20–$25/hour", "$100k", "€60.000 - 70.000 per
+// year" or "120-150k". The amount is the first one marked with a currency or
+// a k/m suffix, or else the first one; a second amount is only the top of a
+// range when a dash or "to" joins the two. Numbers glued to letters,
+// parentheses or "%" ("401(k)", "Q4", "10%") are skipped. Without a period
+// marker, amounts under 500 are taken as hourly and larger ones as yearly.
+// The annual fields are left zero; see Annualize. ok is false when the text
+// has no amount.
+func Parse(s string) (r domain.SalaryRange, ok bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return r, false
+	}
+
+	// Amounts not glued to other text, and which of them start a range.
+	var matches [][]int
+	for _, m := range amountPattern.FindAllStringSubmatchIndex(s, -1) {
+		m[1] = m[0] + len(strings.TrimRightFunc(s[m[0]:m[1]], unicode.IsSpace))
+		if !glued(s, m[0], m[1]) {
+			matches = append(matches, m)
+		}
+	}
+	if len(matches) == 0 {
+		return r, false
+	}
+	joined := func(i int) bool {
+		return i+1 < len(matches) && rangeSeparator.MatchString(stripMarkers(s[matches[i][1]:matches[i+1][0]]))
+	}
+	marked := func(m []int) bool {
+		return m[4] >= 0 || currencyMarked(s[:m[0]])
+	}
+
+	first := 0
+	for i := 0; i < len(matches); i++ {
+		if marked(matches[i]) || joined(i) && marked(matches[i+1]) {
+			first = i
+			break
+		}
+		if joined(i) {
+			i++
+		}
+	}
+	if joined(first) {
+		matches = matches[first : first+2]
+	} else {
+		matches = matches[first : first+1]
+	}
+
+	var (
+		amounts  []float64
+		suffixes []string
+	)
+	for _, m := range matches {
+		v, ok := parseAmount(s[m[2]:m[3]])
+		if !ok {
+			return r, false
+		}
+		amounts = append(amounts, v)
+		suffix := ""
+		if m[4] >= 0 {
+			suffix = strings.ToLower(s[m[4]:m[5]])
+		}
+		suffixes = append(suffixes, suffix)
+	}
+	// "120-150k": the suffix of the second amount applies to the first.
+	if len(amounts) == 2 && suffixes[0] == "" && suffixes[1] != "" && amounts[0] < 1000 {
+		suffixes[0] = suffixes[1]
+	}
+	for i := range amounts {
+		switch suffixes[i] {
+		case "k":
+			amounts[i] *= 1_000
+		case "m":
+			amounts[i] *= 1_000_000
+		}
+	}
+
+	r.Min, r.Max = amounts[0], amounts[0]
+	if len(amounts) == 2 {
+		r.Max = amounts[1]
+		if r.Max < r.Min {
+			r.Min, r.Max = r.Max, r.Min
+		}
+	}
+	if r.Min <= 0 {
+		return domain.SalaryRange{}, false
+	}
+
+	r.Currency = currency(s)
+	r.Period = period(s, r.Max)
+	return r, true
+}
+
+// parseAmount reads one number, telling thousands separators ("100,000",
+// "100.000") from decimals ("95.5", "20,50", "120,000.00"): the last
+// separator is a decimal point unless three digits follow it.
+func parseAmount(s string) (float64, bool) {
+	s = strings.ReplaceAll(s, " ", "")
+	last := strings.LastIndexAny(s, ",.")
+	if last >= 0 {
+		grouping := strings.NewReplacer(",", "", ".", "")
+		if len(s) > 4 && len(s)-last-1 == 3 {
+			s = grouping.Replace(s)
+		} else {
+			s = grouping.Replace(s[:last]) + "." + s[last+1:]
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil
+}
+
+// glued reports whether the number at s[start:end] is part of a word or a
+// figure other than a salary: "401(k)", "Q4", "10%", "(2)".
+func glued(s string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:start])
+	after, _ := utf8.DecodeRuneInString(s[end:])
+	return unicode.IsLetter(before) || before == '(' ||
+		unicode.IsLetter(after) || after == '(' || after == ')' || after == '%'
+}
+
+// currencyMarked reports whether the text before an amount ends with a
+// currency symbol or code, as in "$120k" or "USD 100,000".
+func currencyMarked(before string) bool {
+	before = strings.ToLower(strings.TrimRightFunc(before, unicode.IsSpace))
+	if m := currencyCodePattern.FindStringIndex(before); m != nil && m[1] == len(before) {
+		return true
+	}
+	for _, c := range currencies {
+		if strings.HasSuffix(before, c.marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripMarkers removes currency and period markers from s, leaving what
+// joins two amounts: "/yr - $" becomes " - ".
+func stripMarkers(s string) string {
+	s = strings.ToLower(currencyCodePattern.ReplaceAllString(s, ""))
+	for _, p := range periods {
+		s = p.pattern.ReplaceAllString(s, "")
+	}
+	for _, c := range currencies {
+		s = strings.ReplaceAll(s, c.marker, "")
+	}
+	return s
+}
+
+func currency(s string) string {
+	if m := currencyCodePattern.FindStringSubmatch(s); m != nil {
+		return strings.ToUpper(m[1])
+	}
+	lower := strings.ToLower(s)
+	for _, c := range currencies {
+		if strings.Contains(lower, c.marker) {
+			return c.code
+		}
+	}
+	return ""
+}
+
+func period(s string, max float64) string {
+	for _, p := range periods {
+		if p.pattern.MatchString(s) {
+			return p.period
+		}
+	}
+	if max < 500 {
+		return domain.SalaryPerHour
+	}
+	return domain.SalaryPerYear
+}
+
+// Annualize fills in r's annual fields, counting hoursPerYear working hours
+// (DefaultHoursPerYear when zero or less) in a year of 8-hour days and
+// 40-hour weeks.
+func Annualize(r *domain.SalaryRange, hoursPerYear float64) {
+	if hoursPerYear <= 0 {
+		hoursPerYear = DefaultHoursPerYear
+	}
+	var factor float64
+	switch r.Period {
+	case domain.SalaryPerHour:
+		factor = hoursPerYear
+	case domain.SalaryPerDay:
+		factor = hoursPerYear / 8
+	case domain.SalaryPerWeek:
+		factor = hoursPerYear / 40
+	case domain.SalaryPerMonth:
+		factor = 12
+	default:
+		factor = 1
+	}
+	r.AnnualMin = r.Min * factor
+	r.AnnualMax = r.Max * factor
+}
@@ -0,0 +1,83 @@
+package salary
+
+import (
+	"testing"
+
+	"jobflow.local/internal/domain"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in       string
+		min, max float64
+		currency string
+		period   string
+	}{
+		{"$20–$25/hour", 20, 25, "USD", domain.SalaryPerHour},
+		{"$100k", 100_000, 100_000, "USD", domain.SalaryPerYear},
+		{"€60.000 - 70.000 per year", 60_000, 70_000, "EUR", domain.SalaryPerYear},
+		{"120-150k", 120_000, 150_000, "", domain.SalaryPerYear},
+		{"$120,000.00/yr - $150,000.00/yr", 120_000, 150_000, "USD", domain.SalaryPerYear},
+		{"$120,000.00 - $150,000.00", 120_000, 150_000, "USD", domain.SalaryPerYear},
+		{"$45.50/hr - $55.25/hr", 45.5, 55.25, "USD", domain.SalaryPerHour},
+		{"$45-$55 an hour", 45, 55, "USD", domain.SalaryPerHour},
+		{"£45,000 - £55,000 a year", 45_000, 55_000, "GBP", domain.SalaryPerYear},
+		{"CA$90K/yr - CA$110K/yr", 90_000, 110_000, "CAD", domain.SalaryPerYear},
+		{"1.200,50 € per month", 1200.5, 1200.5, "EUR", domain.SalaryPerMonth},
+		{"100 000 SEK per year", 100_000, 100_000, "SEK", domain.SalaryPerYear},
+		{"CHF 500 per day", 500, 500, "CHF", domain.SalaryPerDay},
+		{"$1,200/week", 1200, 1200, "USD", domain.SalaryPerWeek},
+		{"35", 35, 35, "", domain.SalaryPerHour},
+		{"$150k - $120k", 120_000, 150_000, "USD", domain.SalaryPerYear},
+		{"$1.2M", 1_200_000, 1_200_000, "USD", domain.SalaryPerYear},
+		{"100k to 120k", 100_000, 120_000, "", domain.SalaryPerYear},
+		{"USD 100,000 - 120,000", 100_000, 120_000, "USD", domain.SalaryPerYear},
+		// Other numbers in the text aren't part of the range.
+		{"401(k) match, $130k", 130_000, 130_000, "USD", domain.SalaryPerYear},
+		{"2023 bonus $120k", 120_000, 120_000, "USD", domain.SalaryPerYear},
+		{"$50 per hour, 40 hours/week", 50, 50, "USD", domain.SalaryPerHour},
+		{"10% bonus, $90k - $110k", 90_000, 110_000, "USD", domain.SalaryPerYear},
+		{"Q4 start, 80-95k", 80_000, 95_000, "", domain.SalaryPerYear},
+	}
+	for _, tt := range tests {
+		r, ok := Parse(tt.in)
+		if !ok {
+			t.Errorf("Parse(%q) failed", tt.in)
+			continue
+		}
+		if r.Min != tt.min || r.Max != tt.max || r.Currency != tt.currency || r.Period != tt.period {
+			t.Errorf("Parse(%q) = %v-%v %s per %s, want %v-%v %s per %s",
+				tt.in, r.Min, r.Max, r.Currency, r.Period, tt.min, tt.max, tt.currency, tt.period)
+		}
+	}
+}
+
+func TestParseRejectsTextWithoutAmount(t *testing.T) {
+	for _, in := range []string{"", "   ", "Competitive", "DOE", "$0"} {
+		if r, ok := Parse(in); ok {
+			t.Errorf("Parse(%q) = %+v, want no salary", in, r)
+		}
+	}
+}
+
+func TestAnnualize(t *testing.T) {
+	tests := []struct {
+		period       string
+		hoursPerYear float64
+		want         float64 // annual for an amount of 10
+	}{
+		{domain.SalaryPerHour, 0, 20_800},
+		{domain.SalaryPerHour, 2000, 20_000},
+		{domain.SalaryPerDay, 0, 2_600},
+		{domain.SalaryPerWeek, 0, 520},
+		{domain.SalaryPerMonth, 0, 120},
+		{domain.SalaryPerYear, 0, 10},
+	}
+	for _, tt := range tests {
+		r := domain.SalaryRange{Min: 10, Max: 20, Period: tt.period}
+		Annualize(&r, tt.hoursPerYear)
+		if r.AnnualMin != tt.want || r.AnnualMax != 2*tt.want {
+			t.Errorf("Annualize(%s, %v) = %v-%v, want %v-%v", tt.period, tt.hoursPerYear, r.AnnualMin, r.AnnualMax, tt.want, 2*tt.want)
+		}
+	}
+}
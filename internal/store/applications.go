@@ -0,0 +1,419 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"jobflow.local/internal/domain"
+)
+
+// ErrInvalidCursor is returned by ListApplications for a cursor it did not issue.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// sqliteTimeLayout matches what CURRENT_TIMESTAMP writes, so formatted
+// bounds compare correctly against stored timestamps.
+const sqliteTimeLayout = "2006-01-02 15:04:05"
+
+const applicationColumns = `a.id, a.job_id, a.status, a.outcome, a.notes, a.applied_on, a.interview_time, a.notion_page_id, a.created_at`
+
+// scanApplication reads the columns listed in applicationColumns, followed
+// by any extra destinations.
+func scanApplication(row interface{ Scan(...any) error }, app *domain.Application, extra ...any) error {
+	var (
+		stage         sql.NullString
+		outcome       sql.NullString
+		notes         sql.NullString
+		appliedOn     nullTime
+		interviewTime nullTime
+		notionPageID  sql.NullString
+		createdAt     nullTime
+	)
+
+	dest := append([]any{
+		&app.ID,
+		&app.JobID,
+		&stage,
+		&outcome,
+		&notes,
+		&appliedOn,
+		&interviewTime,
+		&notionPageID,
+		&createdAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+
+	app.Stage = stage.String
+	app.Outcome = outcome.String
+	app.Notes = notes.String
+	app.AppliedOn = appliedOn.ptr()
+	app.InterviewTime = interviewTime.ptr()
+	app.NotionPageID = nil
+	if notionPageID.Valid && notionPageID.String != "" {
+		app.NotionPageID = &notionPageID.String
+	}
+	app.CreatedAt = createdAt.Time
+	return nil
+}
+
+// GetApplication loads a single application by id.
+// Returns ErrNotFound if there is no such row.
+func (s *Store) GetApplication(ctx context.Context, id int64) (domain.Application, error) {
+	var app domain.Application
+	err := scanApplication(s.DB.QueryRowContext(ctx,
+		`SELECT `+applicationColumns+` FROM applications a WHERE a.id = ?`,
+		id,
+	), &app)
+	if err == sql.ErrNoRows {
+		return domain.Application{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Application{}, err
+	}
+	return app, nil
+}
+
+// ApplicationFilter selects, orders and pages the rows of ListApplications.
+// Zero values mean "no constraint".
+type ApplicationFilter struct {
+	Stage       string
+	Outcome     string
+	Company     string // case-insensitive exact match
+	WorkMode    string // case-insensitive exact match
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time // exclusive
+	HasNotion   *bool
+	MinSalary   *float64 // annual; jobs without a parsed salary never match
+
+	SortBy string // one of ApplicationSortFields; defaults to "created_at"
+	Desc   bool
+	Limit  int
+	Cursor string // NextCursor from a previous page
+}
+
+// ApplicationSortFields maps the accepted SortBy values to SQL expressions.
+// Every expression yields text so one cursor format works for all of them.
+var ApplicationSortFields = map[string]string{
+	"created_at": `COALESCE(a.created_at, '')`,
+	"stage":      `COALESCE(a.status, '')`,
+	"outcome":    `COALESCE(a.outcome, '')`,
+	"company":    `LOWER(COALESCE(j.company, ''))`,
+	"work_mode":  `LOWER(COALESCE(j.work_mode, ''))`,
+	"has_notion": `CASE WHEN COALESCE(a.notion_page_id, '') = '' THEN '0' ELSE '1' END`,
+	"fit":        `COALESCE(substr('000' || ` + currentFitScore + `, -3), '')`, // unscored jobs sort lowest
+	"salary":     `CASE WHEN j.salary_annual_max IS NULL THEN '' ELSE printf('%015.2f', j.salary_annual_max) END`,
+}
+
+// ApplicationListItem is an application joined with its job.
+type ApplicationListItem struct {
+	Application domain.Application
+	Job         domain.Job
+	FitScore    *int // against the current resume; nil if not scored
+}
+
+// ApplicationPage is one page of ListApplications. NextCursor is empty on
+// the last page.
+type ApplicationPage struct {
+	Items      []ApplicationListItem
+	NextCursor string
+}
+
+// listCursor is the keyset position after the last row of a page.
+type listCursor struct {
+	SortBy string `json:"s"`
+	Desc   bool   `json:"d"`
+	Key    string `json:"k"`
+	ID     int64  `json:"i"`
+}
+
+func (c listCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var c listCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// ListApplications returns applications joined with their jobs, filtered and
+// sorted per f, using keyset pagination on (sort key, id).
+func (s *Store) ListApplications(ctx context.Context, f ApplicationFilter) (ApplicationPage, error) {
+	if f.SortBy == "" {
+		f.SortBy = "created_at"
+	}
+	sortExpr, ok := ApplicationSortFields[f.SortBy]
+	if !ok {
+		return ApplicationPage{}, fmt.Errorf("unknown sort field %q", f.SortBy)
+	}
+	if f.Limit <= 0 {
+		f.Limit = 50
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+	if f.Stage != "" {
+		where = append(where, `a.status = ?`)
+		args = append(args, f.Stage)
+	}
+	if f.Outcome != "" {
+		where = append(where, `a.outcome = ?`)
+		args = append(args, f.Outcome)
+	}
+	if f.Company != "" {
+		where = append(where, `LOWER(j.company) = LOWER(?)`)
+		args = append(args, f.Company)
+	}
+	if f.WorkMode != "" {
+		where = append(where, `LOWER(j.work_mode) = LOWER(?)`)
+		args = append(args, f.WorkMode)
+	}
+	if f.CreatedFrom != nil {
+		where = append(where, `a.created_at >= ?`)
+		args = append(args, f.CreatedFrom.UTC().Format(sqliteTimeLayout))
+	}
+	if f.CreatedTo != nil {
+		where = append(where, `a.created_at < ?`)
+		args = append(args, f.CreatedTo.UTC().Format(sqliteTimeLayout))
+	}
+	if f.HasNotion != nil {
+		if *f.HasNotion {
+			where = append(where, `COALESCE(a.notion_page_id, '') != ''`)
+		} else {
+			where = append(where, `COALESCE(a.notion_page_id, '') = ''`)
+		}
+	}
+	if f.MinSalary != nil {
+		where = append(where, `j.salary_annual_max >= ?`)
+		args = append(args, *f.MinSalary)
+	}
+
+	cmp, dir := ">", "ASC"
+	if f.Desc {
+		cmp, dir = "<", "DESC"
+	}
+	if f.Cursor != "" {
+		c, err := decodeCursor(f.Cursor)
+		if err != nil {
+			return ApplicationPage{}, err
+		}
+		if c.SortBy != f.SortBy || c.Desc != f.Desc {
+			return ApplicationPage{}, ErrInvalidCursor
+		}
+		where = append(where, fmt.Sprintf(`(%[1]s %[2]s ? OR (%[1]s = ? AND a.id %[2]s ?))`, sortExpr, cmp))
+		args = append(args, c.Key, c.Key, c.ID)
+	}
+
+	query := `
+		SELECT ` + applicationColumns + `,
+			COALESCE(j.external_id, ''),
+			COALESCE(j.title, ''),
+			COALESCE(j.company, ''),
+			COALESCE(j.location, ''),
+			COALESCE(j.url, ''),
+			COALESCE(j.work_mode, ''),
+			COALESCE(j.salary, ''),
+			COALESCE(j.location_city, ''),
+			COALESCE(j.location_region, ''),
+			COALESCE(j.location_country, ''),
+			COALESCE(j.remote_scope, ''),
+			` + jobSalaryColumns + `,
+			` + currentFitScore + `,
+			` + sortExpr + `
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id`
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf("\n\t\tORDER BY %s %s, a.id %s\n\t\tLIMIT ?", sortExpr, dir, dir)
+	// Fetch one extra row to learn whether another page exists.
+	args = append(args, f.Limit+1)
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return ApplicationPage{}, err
+	}
+	defer rows.Close()
+
+	var (
+		page ApplicationPage
+		keys []string
+	)
+	for rows.Next() {
+		var (
+			it  ApplicationListItem
+			ss  salaryScan
+			fit sql.NullInt64
+			key string
+		)
+		dest := append([]any{
+			&it.Job.ExternalID,
+			&it.Job.Title,
+			&it.Job.Company,
+			&it.Job.Location,
+			&it.Job.URL,
+			&it.Job.WorkMode,
+			&it.Job.Salary,
+			&it.Job.Place.City,
+			&it.Job.Place.Region,
+			&it.Job.Place.Country,
+			&it.Job.Place.RemoteScope,
+		}, ss.dest()...)
+		if err := scanApplication(rows, &it.Application, append(dest, &fit, &key)...); err != nil {
+			return ApplicationPage{}, err
+		}
+		it.Job.ID = it.Application.JobID
+		it.Job.SalaryRange = ss.value()
+		if fit.Valid {
+			score := int(fit.Int64)
+			it.FitScore = &score
+		}
+		page.Items = append(page.Items, it)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return ApplicationPage{}, err
+	}
+
+	if len(page.Items) > f.Limit {
+		page.Items = page.Items[:f.Limit]
+		last := f.Limit - 1
+		page.NextCursor = listCursor{
+			SortBy: f.SortBy,
+			Desc:   f.Desc,
+			Key:    keys[last],
+			ID:     page.Items[last].Application.ID,
+		}.encode()
+	}
+	return page, nil
+}
+
+// ApplicationPatch lists the application fields to change; nil fields are
+// left as they are.
+type ApplicationPatch struct {
+	Stage              *string
+	Outcome            *string
+	Notes              *string
+	InterviewTime      *time.Time
+	ClearInterviewTime bool
+}
+
+// UpdateApplication applies patch to an application, logs stage/outcome
+// transitions with the given source, and returns the updated row. Unless the
+// change came from Notion, the new values are queued for its Notion page.
+// Returns ErrNotFound if there is no such application.
+func (s *Store) UpdateApplication(ctx context.Context, id int64, patch ApplicationPatch, source string) (domain.Application, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Application{}, err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var before domain.Application
+	err = scanApplication(tx.QueryRowContext(ctx,
+		`SELECT `+applicationColumns+` FROM applications a WHERE a.id = ?`,
+		id,
+	), &before)
+	if err == sql.ErrNoRows {
+		return domain.Application{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Application{}, err
+	}
+
+	after := before
+	if patch.Stage != nil {
+		after.Stage = *patch.Stage
+	}
+	if patch.Outcome != nil {
+		after.Outcome = *patch.Outcome
+	}
+	if patch.Notes != nil {
+		after.Notes = *patch.Notes
+	}
+	if patch.InterviewTime != nil {
+		after.InterviewTime = patch.InterviewTime
+	}
+	if patch.ClearInterviewTime {
+		after.InterviewTime = nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE applications
+		SET status = ?, outcome = ?, notes = ?, interview_time = ?
+		WHERE id = ?`,
+		after.Stage,
+		after.Outcome,
+		after.Notes,
+		timeArg(after.InterviewTime),
+		id,
+	)
+	if err != nil {
+		return domain.Application{}, err
+	}
+
+	if err := recordApplicationChanges(ctx, tx, id, before, after, source); err != nil {
+		return domain.Application{}, err
+	}
+
+	if source != domain.SourceNotion && after.NotionPageID != nil {
+		if err := enqueueNotionWrite(ctx, tx, id, OutboxOpApplication, nil); err != nil {
+			return domain.Application{}, err
+		}
+	}
+
+	committed = true
+	return after, tx.Commit()
+}
+
+// ListApplicationsByNotionPageID returns the applications linked to a Notion
+// page, oldest first; re-applying to a job links the new application to the
+// job's existing page. Returns ErrNotFound if no application points to it.
+func (s *Store) ListApplicationsByNotionPageID(ctx context.Context, pageID string) ([]domain.Application, error) {
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT `+applicationColumns+` FROM applications a WHERE a.notion_page_id = ? ORDER BY a.id`,
+		pageID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apps []domain.Application
+	for rows.Next() {
+		var app domain.Application
+		if err := scanApplication(rows, &app); err != nil {
+			return nil, err
+		}
+		apps = append(apps, app)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(apps) == 0 {
+		return nil, ErrNotFound
+	}
+	return apps, nil
+}
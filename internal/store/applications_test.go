@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"jobflow.local/internal/domain"
+)
+
+func TestInterviewTimeRoundTripsWithOffset(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+
+	est := time.FixedZone("", -5*60*60)
+	at := time.Date(2025, 11, 20, 15, 0, 0, 0, est)
+
+	job := domain.Job{ExternalID: "offset-1", Title: "Engineer", Company: "Acme"}
+	app := domain.Application{Stage: "Applied", InterviewTime: &at}
+	if err := st.UpsertJobAndApplication(ctx, &job, &app, nil, domain.SourceAPI); err != nil {
+		t.Fatal(err)
+	}
+	got, err := st.GetApplication(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("get after insert: %v", err)
+	}
+	if got.InterviewTime == nil || !got.InterviewTime.Equal(at) {
+		t.Fatalf("interview time after insert = %v, want %v", got.InterviewTime, at)
+	}
+
+	later := at.Add(24 * time.Hour)
+	if _, err := st.UpdateApplication(ctx, app.ID, ApplicationPatch{InterviewTime: &later}, domain.SourceAPI); err != nil {
+		t.Fatal(err)
+	}
+	got, err = st.GetApplication(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("get after update: %v", err)
+	}
+	if got.InterviewTime == nil || !got.InterviewTime.Equal(later) {
+		t.Fatalf("interview time after update = %v, want %v", got.InterviewTime, later)
+	}
+
+	page, err := st.ListApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Fatalf("listed %d applications, want 1", len(page.Items))
+	}
+}
+
+// Rows written before times were stored in UTC hold Go's time.String form.
+func TestScanApplicationReadsLegacyTimes(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	_, app := saveTestJob(t, st, domain.Job{ExternalID: "legacy-1", Title: "Engineer"})
+
+	for _, stored := range []string{
+		"2025-11-20 15:00:00 -0500 -0500",
+		"2025-11-20 21:00:00 +0100 CET",
+		"2025-11-20 20:00:00 +0000 UTC",
+		"2025-11-20T15:00:00-05:00",
+		"2025-11-20 20:00:00",
+	} {
+		if _, err := st.DB.ExecContext(ctx, `UPDATE applications SET interview_time = ? WHERE id = ?`, stored, app.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err := st.GetApplication(ctx, app.ID)
+		if err != nil {
+			t.Errorf("%q: %v", stored, err)
+			continue
+		}
+		want := time.Date(2025, 11, 20, 20, 0, 0, 0, time.UTC)
+		if got.InterviewTime == nil || !got.InterviewTime.Equal(want) {
+			t.Errorf("%q: interview time = %v, want %v", stored, got.InterviewTime, want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+
+	"jobflow.local/internal/domain"
+)
+
+const contactColumns = `id, job_id, COALESCE(name, ''), COALESCE(email, ''), COALESCE(role, ''), COALESCE(notes, ''), source, status, confidence`
+
+func scanContact(row interface{ Scan(...any) error }, c *domain.Contact) error {
+	return row.Scan(&c.ID, &c.JobID, &c.Name, &c.Email, &c.Role, &c.Notes, &c.Source, &c.Status, &c.Confidence)
+}
+
+// CreateContact inserts a contact for c.JobID and sets c.ID. Empty Source and
+// Status default to a manual, accepted contact with confidence 1.
+func (s *Store) CreateContact(ctx context.Context, c *domain.Contact) error {
+	if c.Source == "" {
+		c.Source = domain.ContactSourceManual
+	}
+	if c.Status == "" {
+		c.Status = domain.ContactStatusAccepted
+	}
+	if c.Source == domain.ContactSourceManual {
+		c.Confidence = 1
+	}
+	res, err := s.DB.ExecContext(ctx, `
+		INSERT INTO contacts (job_id, name, email, role, notes, source, status, confidence)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.JobID,
+		c.Name,
+		c.Email,
+		c.Role,
+		c.Notes,
+		c.Source,
+		c.Status,
+		c.Confidence,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	c.ID = id
+	return nil
+}
+
+// ListContacts returns the contacts attached to a job, oldest first. An empty
+// status lists every contact that wasn't discarded.
+func (s *Store) ListContacts(ctx context.Context, jobID int64, status string) ([]domain.Contact, error) {
+	if status == "" {
+		return s.listContacts(ctx, `WHERE job_id = ? AND status != ? ORDER BY id`, jobID, domain.ContactStatusDiscarded)
+	}
+	return s.listContacts(ctx, `WHERE job_id = ? AND status = ? ORDER BY id`, jobID, status)
+}
+
+// ListContactsByStatus returns the contacts of every job with the given
+// status, oldest first; used for the review queue.
+func (s *Store) ListContactsByStatus(ctx context.Context, status string) ([]domain.Contact, error) {
+	return s.listContacts(ctx, `WHERE status = ? ORDER BY id`, status)
+}
+
+func (s *Store) listContacts(ctx context.Context, where string, args ...any) ([]domain.Contact, error) {
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT `+contactColumns+` FROM contacts `+where,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.Contact
+	for rows.Next() {
+		var c domain.Contact
+		if err := scanContact(rows, &c); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// GetContact loads one contact by id.
+// Returns ErrNotFound if there is no such row.
+func (s *Store) GetContact(ctx context.Context, id int64) (domain.Contact, error) {
+	var c domain.Contact
+	err := scanContact(s.DB.QueryRowContext(ctx,
+		`SELECT `+contactColumns+` FROM contacts WHERE id = ?`,
+		id,
+	), &c)
+	if err == sql.ErrNoRows {
+		return domain.Contact{}, ErrNotFound
+	}
+	return c, err
+}
+
+// UpdateContact overwrites the editable fields of an existing contact.
+func (s *Store) UpdateContact(ctx context.Context, c domain.Contact) error {
+	res, err := s.DB.ExecContext(ctx, `
+		UPDATE contacts
+		SET name = ?, email = ?, role = ?, notes = ?
+		WHERE id = ?`,
+		c.Name,
+		c.Email,
+		c.Role,
+		c.Notes,
+		c.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res)
+}
+
+// DeleteContact removes a contact, with the outreach drafts written to it.
+func (s *Store) DeleteContact(ctx context.Context, id int64) error {
+	res, err := s.DB.ExecContext(ctx, `DELETE FROM contacts WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res)
+}
+
+// SetContactStatus records a review decision on a contact.
+func (s *Store) SetContactStatus(ctx context.Context, id int64, status string) error {
+	res, err := s.DB.ExecContext(ctx, `UPDATE contacts SET status = ? WHERE id = ?`, status, id)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res)
+}
+
+// SuggestContacts stores extracted contacts for a job as suggested, skipping
+// any that match a contact the job already has (in any status, so discarded
+// ones stay discarded) by email or name. It returns the contacts it added.
+func (s *Store) SuggestContacts(ctx context.Context, jobID int64, found []domain.Contact) ([]domain.Contact, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT LOWER(COALESCE(name, '')), LOWER(COALESCE(email, ''))
+		FROM contacts WHERE job_id = ?`,
+		jobID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]bool{}
+	for rows.Next() {
+		var name, email string
+		if err := rows.Scan(&name, &email); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		for _, k := range contactKeys(name, email) {
+			known[k] = true
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var added []domain.Contact
+	for _, c := range found {
+		keys := contactKeys(c.Name, c.Email)
+		if len(keys) == 0 || slices.ContainsFunc(keys, func(k string) bool { return known[k] }) {
+			continue
+		}
+		for _, k := range keys {
+			known[k] = true
+		}
+
+		c.JobID = jobID
+		c.Status = domain.ContactStatusSuggested
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO contacts (job_id, name, email, role, notes, source, status, confidence)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id`,
+			c.JobID,
+			c.Name,
+			c.Email,
+			c.Role,
+			c.Notes,
+			c.Source,
+			c.Status,
+			c.Confidence,
+		).Scan(&c.ID)
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, c)
+	}
+
+	committed = true
+	return added, tx.Commit()
+}
+
+// contactKeys identifies a contact by its email and by its name, so a
+// person found again with or without an email still matches.
+func contactKeys(name, email string) []string {
+	var keys []string
+	if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+		keys = append(keys, "email:"+email)
+	}
+	if name = strings.ToLower(strings.Join(strings.Fields(name), " ")); name != "" {
+		keys = append(keys, "name:"+name)
+	}
+	return keys
+}
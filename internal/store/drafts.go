@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"jobflow.local/internal/domain"
+)
+
+// saveDraftAttempts bounds how often SaveDraft picks a new version after
+// losing the race for one to a concurrent save.
+const saveDraftAttempts = 5
+
+// SaveDraft stores d as the next version for its application, kind and
+// contact, and sets d.ID, d.Version and d.CreatedAt.
+func (s *Store) SaveDraft(ctx context.Context, d *domain.Draft) error {
+	var err error
+	for range saveDraftAttempts {
+		err = s.DB.QueryRowContext(ctx, `
+			INSERT INTO drafts (application_id, contact_id, kind, version, tone, content, model, prompt_version)
+			VALUES (?, ?, ?,
+				(SELECT COALESCE(MAX(version), 0) + 1 FROM drafts WHERE application_id = ? AND kind = ? AND contact_id IS ?),
+				?, ?, ?, ?)
+			RETURNING id, version, created_at`,
+			d.ApplicationID,
+			d.ContactID,
+			d.Kind,
+			d.ApplicationID,
+			d.Kind,
+			d.ContactID,
+			d.Tone,
+			d.Content,
+			d.Model,
+			d.PromptVersion,
+		).Scan(&d.ID, &d.Version, &d.CreatedAt)
+		// Another save took the same version in between; read MAX again.
+		if !isUniqueViolation(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// DraftFilter narrows ListDrafts; zero fields match everything.
+type DraftFilter struct {
+	Kind      string
+	ContactID *int64
+}
+
+// ListDrafts returns the drafts of an application, newest first.
+func (s *Store) ListDrafts(ctx context.Context, appID int64, f DraftFilter) ([]domain.Draft, error) {
+	where := []string{`application_id = ?`}
+	args := []any{appID}
+	if f.Kind != "" {
+		where = append(where, `kind = ?`)
+		args = append(args, f.Kind)
+	}
+	if f.ContactID != nil {
+		where = append(where, `contact_id = ?`)
+		args = append(args, *f.ContactID)
+	}
+
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT id, application_id, contact_id, kind, version, COALESCE(tone, ''), content,
+			COALESCE(model, ''), COALESCE(prompt_version, ''), created_at
+		FROM drafts
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.Draft
+	for rows.Next() {
+		var (
+			d         domain.Draft
+			contactID sql.NullInt64
+		)
+		if err := rows.Scan(
+			&d.ID,
+			&d.ApplicationID,
+			&contactID,
+			&d.Kind,
+			&d.Version,
+			&d.Tone,
+			&d.Content,
+			&d.Model,
+			&d.PromptVersion,
+			&d.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if contactID.Valid {
+			d.ContactID = &contactID.Int64
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"jobflow.local/internal/domain"
+)
+
+func TestSaveDraftVersionsPerContact(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	job, app := saveTestJob(t, st, domain.Job{ExternalID: "drafts-1", Title: "Engineer"})
+
+	alice := domain.Contact{JobID: job.ID, Name: "Alice"}
+	bob := domain.Contact{JobID: job.ID, Name: "Bob"}
+	for _, c := range []*domain.Contact{&alice, &bob} {
+		if err := st.CreateContact(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	save := func(kind string, contactID *int64) domain.Draft {
+		t.Helper()
+		d := domain.Draft{ApplicationID: app.ID, ContactID: contactID, Kind: kind, Content: "Hello"}
+		if err := st.SaveDraft(ctx, &d); err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	for _, tt := range []struct {
+		kind    string
+		contact *int64
+		want    int
+	}{
+		{domain.DraftCoverLetter, nil, 1},
+		{domain.DraftCoverLetter, nil, 2},
+		{domain.DraftOutreach, &alice.ID, 1},
+		{domain.DraftOutreach, &bob.ID, 1},
+		{domain.DraftOutreach, &alice.ID, 2},
+	} {
+		if d := save(tt.kind, tt.contact); d.Version != tt.want {
+			t.Errorf("%s draft version = %d, want %d", tt.kind, d.Version, tt.want)
+		}
+	}
+}
+
+// Outreach drafts go with their contact; deleting contacts whose drafts
+// share a version number must not collide.
+func TestDeleteContactsWithDrafts(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	job, app := saveTestJob(t, st, domain.Job{ExternalID: "drafts-2", Title: "Engineer"})
+
+	var ids []int64
+	for _, name := range []string{"Alice", "Bob"} {
+		c := domain.Contact{JobID: job.ID, Name: name}
+		if err := st.CreateContact(ctx, &c); err != nil {
+			t.Fatal(err)
+		}
+		d := domain.Draft{ApplicationID: app.ID, ContactID: &c.ID, Kind: domain.DraftOutreach, Content: "Hi " + name}
+		if err := st.SaveDraft(ctx, &d); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, c.ID)
+	}
+	cover := domain.Draft{ApplicationID: app.ID, Kind: domain.DraftCoverLetter, Content: "Dear team"}
+	if err := st.SaveDraft(ctx, &cover); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range ids {
+		if err := st.DeleteContact(ctx, id); err != nil {
+			t.Fatalf("delete contact %d: %v", id, err)
+		}
+	}
+
+	drafts, err := st.ListDrafts(ctx, app.ID, DraftFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drafts) != 1 || drafts[0].Kind != domain.DraftCoverLetter {
+		t.Errorf("drafts left = %+v, want only the cover letter", drafts)
+	}
+}
+
+func TestSaveDraftConcurrently(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	_, app := saveTestJob(t, st, domain.Job{ExternalID: "drafts-1", Title: "Engineer"})
+
+	const n = 8
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		versions []int
+	)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d := domain.Draft{ApplicationID: app.ID, Kind: domain.DraftCoverLetter, Content: "Hello"}
+			if err := st.SaveDraft(ctx, &d); err != nil {
+				t.Errorf("save: %v", err)
+				return
+			}
+			mu.Lock()
+			versions = append(versions, d.Version)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Ints(versions)
+	for i, v := range versions {
+		if v != i+1 {
+			t.Fatalf("versions = %v, want 1 to %d", versions, n)
+		}
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	_, app := saveTestJob(t, st, domain.Job{ExternalID: "drafts-1", Title: "Engineer"})
+
+	insert := func() error {
+		_, err := st.DB.ExecContext(ctx,
+			`INSERT INTO drafts (application_id, kind, version, content) VALUES (?, ?, 1, 'Hello')`,
+			app.ID, domain.DraftCoverLetter)
+		return err
+	}
+	if err := insert(); err != nil {
+		t.Fatal(err)
+	}
+	err := insert()
+	if !isUniqueViolation(err) {
+		t.Errorf("isUniqueViolation(%v) = false, want true", err)
+	}
+	if isUniqueViolation(ErrNotFound) {
+		t.Error("isUniqueViolation(ErrNotFound) = true")
+	}
+}
@@ -0,0 +1,186 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"jobflow.local/internal/domain"
+)
+
+// JobEmbedding is the vector of one job under one embedding model.
+type JobEmbedding struct {
+	JobID       int64
+	Model       string
+	Vector      []float32
+	ContentHash string // of the text the vector was computed from
+}
+
+// SaveJobEmbedding stores e, replacing the job's previous vector for the
+// same model and clearing any recorded failure.
+func (s *Store) SaveJobEmbedding(ctx context.Context, e JobEmbedding) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO job_embeddings (job_id, model, dims, vector, content_hash)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(job_id, model) DO UPDATE SET
+			dims = excluded.dims,
+			vector = excluded.vector,
+			content_hash = excluded.content_hash,
+			created_at = CURRENT_TIMESTAMP`,
+		e.JobID,
+		e.Model,
+		len(e.Vector),
+		encodeVector(e.Vector),
+		e.ContentHash,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM job_embedding_failures WHERE job_id = ? AND model = ?`,
+		e.JobID, e.Model,
+	)
+	if err != nil {
+		return err
+	}
+
+	committed = true
+	return tx.Commit()
+}
+
+// FailJobEmbedding records a failed attempt to embed a job under model.
+// ListJobsWithoutEmbedding leaves the job out for retry, doubled for every
+// further failure in a row and capped at maxRetry.
+func (s *Store) FailJobEmbedding(ctx context.Context, jobID int64, model, errMsg string, retry, maxRetry time.Duration) error {
+	first := int64(min(retry, maxRetry).Seconds())
+	_, err := s.DB.ExecContext(ctx, `
+		INSERT INTO job_embedding_failures (job_id, model, error, next_attempt_at)
+		VALUES (?, ?, ?, datetime('now', printf('+%d seconds', ?)))
+		ON CONFLICT(job_id, model) DO UPDATE SET
+			error = excluded.error,
+			attempts = attempts + 1,
+			next_attempt_at = datetime('now', printf('+%d seconds', min(?, ? << min(attempts, 30))))`,
+		jobID, model, errMsg, first,
+		int64(maxRetry.Seconds()), int64(retry.Seconds()),
+	)
+	return err
+}
+
+// GetJobEmbedding returns a job's vector under model.
+// Returns ErrNotFound if it has none.
+func (s *Store) GetJobEmbedding(ctx context.Context, jobID int64, model string) (JobEmbedding, error) {
+	e := JobEmbedding{JobID: jobID, Model: model}
+	var blob []byte
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT vector, content_hash
+		FROM job_embeddings
+		WHERE job_id = ? AND model = ?`,
+		jobID, model,
+	).Scan(&blob, &e.ContentHash)
+	if err == sql.ErrNoRows {
+		return JobEmbedding{}, ErrNotFound
+	}
+	if err != nil {
+		return JobEmbedding{}, err
+	}
+	if e.Vector, err = decodeVector(blob); err != nil {
+		return JobEmbedding{}, fmt.Errorf("job %d: %w", jobID, err)
+	}
+	return e, nil
+}
+
+// ListJobEmbeddings returns every vector stored under model.
+func (s *Store) ListJobEmbeddings(ctx context.Context, model string) ([]JobEmbedding, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT job_id, vector, content_hash
+		FROM job_embeddings
+		WHERE model = ?
+		ORDER BY job_id`,
+		model,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []JobEmbedding
+	for rows.Next() {
+		e := JobEmbedding{Model: model}
+		var blob []byte
+		if err := rows.Scan(&e.JobID, &blob, &e.ContentHash); err != nil {
+			return nil, err
+		}
+		if e.Vector, err = decodeVector(blob); err != nil {
+			return nil, fmt.Errorf("job %d: %w", e.JobID, err)
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// ListJobsWithoutEmbedding returns up to limit jobs that have a description
+// but no vector under model, oldest first. Jobs whose last attempt failed
+// are left out until their next attempt is due.
+func (s *Store) ListJobsWithoutEmbedding(ctx context.Context, model string, limit int) ([]domain.Job, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs j
+		WHERE COALESCE(j.description, '') != ''
+		  AND NOT EXISTS (SELECT 1 FROM job_embeddings e WHERE e.job_id = j.id AND e.model = ?)
+		  AND NOT EXISTS (
+			SELECT 1 FROM job_embedding_failures f
+			WHERE f.job_id = j.id AND f.model = ? AND f.next_attempt_at > CURRENT_TIMESTAMP
+		  )
+		ORDER BY j.id
+		LIMIT ?`,
+		model, model, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		var job domain.Job
+		if err := scanJob(rows, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func encodeVector(v []float32) []byte {
+	b := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(f))
+	}
+	return b
+}
+
+func decodeVector(b []byte) ([]float32, error) {
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("corrupt vector of %d bytes", len(b))
+	}
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v, nil
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"jobflow.local/internal/domain"
+)
+
+func TestJobEmbeddingRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	job, _ := saveTestJob(t, st, domain.Job{ExternalID: "emb-1", Title: "Go", Description: "Go developer"})
+
+	if _, err := st.GetJobEmbedding(ctx, job.ID, "m"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("get before save: err = %v, want ErrNotFound", err)
+	}
+
+	want := []float32{0.5, -0.25, 1, 0}
+	if err := st.SaveJobEmbedding(ctx, JobEmbedding{JobID: job.ID, Model: "m", Vector: want, ContentHash: "h1"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	got, err := st.GetJobEmbedding(ctx, job.ID, "m")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.ContentHash != "h1" || len(got.Vector) != len(want) {
+		t.Fatalf("got %+v, want vector %v with hash h1", got, want)
+	}
+	for i := range want {
+		if got.Vector[i] != want[i] {
+			t.Errorf("vector[%d] = %v, want %v", i, got.Vector[i], want[i])
+		}
+	}
+
+	all, err := st.ListJobEmbeddings(ctx, "m")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(all) != 1 || all[0].JobID != job.ID {
+		t.Errorf("list = %+v, want job %d only", all, job.ID)
+	}
+}
+
+func TestListJobsWithoutEmbeddingSkipsFailedJobs(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	failing, _ := saveTestJob(t, st, domain.Job{ExternalID: "emb-1", Title: "A", Description: "a"})
+	other, _ := saveTestJob(t, st, domain.Job{ExternalID: "emb-2", Title: "B", Description: "b"})
+	saveTestJob(t, st, domain.Job{ExternalID: "emb-3", Title: "No description"})
+
+	pending := func() []int64 {
+		t.Helper()
+		jobs, err := st.ListJobsWithoutEmbedding(ctx, "m", 10)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		var ids []int64
+		for _, j := range jobs {
+			ids = append(ids, j.ID)
+		}
+		return ids
+	}
+
+	if ids := pending(); len(ids) != 2 {
+		t.Fatalf("pending = %v, want both jobs with a description", ids)
+	}
+
+	if err := st.FailJobEmbedding(ctx, failing.ID, "m", "HTTP 400", time.Hour, 24*time.Hour); err != nil {
+		t.Fatalf("fail: %v", err)
+	}
+	if err := st.FailJobEmbedding(ctx, failing.ID, "m", "HTTP 400", time.Hour, 24*time.Hour); err != nil {
+		t.Fatalf("fail again: %v", err)
+	}
+	if ids := pending(); len(ids) != 1 || ids[0] != other.ID {
+		t.Fatalf("pending after failure = %v, want [%d]", ids, other.ID)
+	}
+	if jobs, err := st.ListJobsWithoutEmbedding(ctx, "other-model", 10); err != nil || len(jobs) != 2 {
+		t.Errorf("failure under one model hid jobs from another: %d jobs, err %v", len(jobs), err)
+	}
+
+	// A due retry brings the job back; saving a vector clears the failure.
+	if _, err := st.DB.ExecContext(ctx, `UPDATE job_embedding_failures SET next_attempt_at = datetime('now', '-1 second')`); err != nil {
+		t.Fatal(err)
+	}
+	if ids := pending(); len(ids) != 2 {
+		t.Fatalf("pending once due = %v, want both jobs", ids)
+	}
+	if err := st.SaveJobEmbedding(ctx, JobEmbedding{JobID: failing.ID, Model: "m", Vector: []float32{1}}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	var n int
+	if err := st.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM job_embedding_failures`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("%d failures left after saving the vector, want 0", n)
+	}
+}
+
+func TestFailJobEmbeddingBacksOff(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	job, _ := saveTestJob(t, st, domain.Job{ExternalID: "emb-1", Title: "A", Description: "a"})
+
+	wait := func() time.Duration {
+		t.Helper()
+		var secs int64
+		err := st.DB.QueryRowContext(ctx, `
+			SELECT CAST(strftime('%s', next_attempt_at) AS INTEGER) - CAST(strftime('%s', 'now') AS INTEGER)
+			FROM job_embedding_failures WHERE job_id = ?`, job.ID).Scan(&secs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return time.Duration(secs) * time.Second
+	}
+
+	for i, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute} {
+		if err := st.FailJobEmbedding(ctx, job.ID, "m", "down", time.Minute, 5*time.Minute); err != nil {
+			t.Fatalf("fail %d: %v", i+1, err)
+		}
+		if got := wait(); got < want-2*time.Second || got > want {
+			t.Errorf("after %d failures wait = %v, want %v", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"jobflow.local/internal/domain"
+)
+
+// Enrichment queue statuses. Rows are kept once done or failed so clients
+// can poll the outcome.
+const (
+	EnrichmentPending = "pending"
+	EnrichmentRunning = "running"
+	EnrichmentDone    = "done"
+	EnrichmentFailed  = "failed"
+)
+
+// EnrichmentTask is one queued LLM enrichment of an application's job.
+type EnrichmentTask struct {
+	ID            int64
+	ApplicationID int64
+	Status        string
+	Attempts      int // including the current one while running
+	LastError     string
+	EnrichmentID  *int64 // job_enrichments row, set once done
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+const enrichmentColumns = `id, application_id, status, attempts, COALESCE(last_error, ''), enrichment_id, next_attempt_at, created_at, updated_at`
+
+func scanEnrichmentTask(row interface{ Scan(...any) error }, t *EnrichmentTask) error {
+	var enrichmentID sql.NullInt64
+	if err := row.Scan(
+		&t.ID,
+		&t.ApplicationID,
+		&t.Status,
+		&t.Attempts,
+		&t.LastError,
+		&enrichmentID,
+		&t.NextAttemptAt,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	t.EnrichmentID = nil
+	if enrichmentID.Valid {
+		t.EnrichmentID = &enrichmentID.Int64
+	}
+	return nil
+}
+
+// EnqueueEnrichment queues the enrichment of an application.
+func (s *Store) EnqueueEnrichment(ctx context.Context, appID int64) (EnrichmentTask, error) {
+	res, err := s.DB.ExecContext(ctx,
+		`INSERT INTO enrichment_queue (application_id) VALUES (?)`,
+		appID,
+	)
+	if err != nil {
+		return EnrichmentTask{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return EnrichmentTask{}, err
+	}
+
+	var t EnrichmentTask
+	err = scanEnrichmentTask(s.DB.QueryRowContext(ctx,
+		`SELECT `+enrichmentColumns+` FROM enrichment_queue WHERE id = ?`, id,
+	), &t)
+	return t, err
+}
+
+// GetLatestEnrichment returns the most recent enrichment task of an
+// application. Returns ErrNotFound if it was never queued.
+func (s *Store) GetLatestEnrichment(ctx context.Context, appID int64) (EnrichmentTask, error) {
+	var t EnrichmentTask
+	err := scanEnrichmentTask(s.DB.QueryRowContext(ctx,
+		`SELECT `+enrichmentColumns+` FROM enrichment_queue WHERE application_id = ? ORDER BY id DESC LIMIT 1`,
+		appID,
+	), &t)
+	if err == sql.ErrNoRows {
+		return EnrichmentTask{}, ErrNotFound
+	}
+	return t, err
+}
+
+// ClaimEnrichment marks the oldest due task as running and returns it. It
+// reports false when nothing is due. The single UPDATE makes the claim safe
+// across workers.
+func (s *Store) ClaimEnrichment(ctx context.Context) (EnrichmentTask, bool, error) {
+	var t EnrichmentTask
+	err := scanEnrichmentTask(s.DB.QueryRowContext(ctx, `
+		UPDATE enrichment_queue
+		SET status = ?, attempts = attempts + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM enrichment_queue
+			WHERE status = ? AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY id
+			LIMIT 1
+		)
+		RETURNING `+enrichmentColumns,
+		EnrichmentRunning, EnrichmentPending,
+	), &t)
+	if err == sql.ErrNoRows {
+		return EnrichmentTask{}, false, nil
+	}
+	if err != nil {
+		return EnrichmentTask{}, false, err
+	}
+	return t, true, nil
+}
+
+// CompleteEnrichment stores the result of a task as the job's current
+// enrichment (setting enr.ID) and queues it for the application's Notion
+// page body.
+func (s *Store) CompleteEnrichment(ctx context.Context, taskID, appID int64, enr *domain.Enrichment) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err := insertJobEnrichment(ctx, tx, enr); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE enrichment_queue
+		SET status = ?, enrichment_id = ?, last_error = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`,
+		EnrichmentDone, enr.ID, taskID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := enqueueNotionWrite(ctx, tx, appID, OutboxOpEnrichment, enr); err != nil {
+		return err
+	}
+
+	committed = true
+	return tx.Commit()
+}
+
+// FailEnrichment records a failed attempt. With a retry time the task goes
+// back to pending; with nil it is marked failed for good.
+func (s *Store) FailEnrichment(ctx context.Context, taskID int64, errMsg string, retryAt *time.Time) error {
+	status, next := EnrichmentFailed, any(nil)
+	if retryAt != nil {
+		status, next = EnrichmentPending, retryAt.UTC().Format(sqliteTimeLayout)
+	}
+
+	_, err := s.DB.ExecContext(ctx, `
+		UPDATE enrichment_queue
+		SET status = ?,
+			last_error = ?,
+			next_attempt_at = COALESCE(?, next_attempt_at),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`,
+		status, errMsg, next, taskID,
+	)
+	return err
+}
+
+// ResetStaleEnrichments returns tasks left running (e.g. by a crash) to
+// pending. Call it before any worker starts.
+func (s *Store) ResetStaleEnrichments(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx,
+		`UPDATE enrichment_queue SET status = ? WHERE status = ?`,
+		EnrichmentPending, EnrichmentRunning,
+	)
+	return err
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"jobflow.local/internal/domain"
+)
+
+// insertJobEnrichment stores an enrichment and its skills inside the
+// caller's transaction, and sets enr.ID and enr.CreatedAt.
+func insertJobEnrichment(ctx context.Context, tx *sql.Tx, enr *domain.Enrichment) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO job_enrichments (job_id, summary, tailored_note, snippet, model, prompt_version)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at`,
+		enr.JobID,
+		enr.Summary,
+		enr.TailoredNote,
+		enr.Snippet,
+		enr.Model,
+		enr.PromptVersion,
+	).Scan(&enr.ID, &enr.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	enr.Skills = normalizeSkills(enr.Skills)
+	for i, skill := range enr.Skills {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO job_skills (enrichment_id, job_id, position, skill)
+			VALUES (?, ?, ?, ?)`,
+			enr.ID, enr.JobID, i, skill,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeSkills trims and collapses whitespace and drops empty and
+// case-insensitive duplicate entries, keeping the model's order.
+func normalizeSkills(skills []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range skills {
+		s = strings.Join(strings.Fields(s), " ")
+		key := strings.ToLower(s)
+		if s == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, s)
+	}
+	return out
+}
+
+const enrichmentRowColumns = `id, job_id, COALESCE(summary, ''), COALESCE(tailored_note, ''), COALESCE(snippet, ''), COALESCE(model, ''), COALESCE(prompt_version, ''), created_at`
+
+// getJobEnrichment loads one enrichment row selected by where, with its
+// skills. Returns ErrNotFound if no row matches.
+func (s *Store) getJobEnrichment(ctx context.Context, where string, args ...any) (domain.Enrichment, error) {
+	var enr domain.Enrichment
+	err := s.DB.QueryRowContext(ctx,
+		`SELECT `+enrichmentRowColumns+` FROM job_enrichments `+where,
+		args...,
+	).Scan(
+		&enr.ID,
+		&enr.JobID,
+		&enr.Summary,
+		&enr.TailoredNote,
+		&enr.Snippet,
+		&enr.Model,
+		&enr.PromptVersion,
+		&enr.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return domain.Enrichment{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Enrichment{}, err
+	}
+
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT skill FROM job_skills WHERE enrichment_id = ? ORDER BY position`,
+		enr.ID,
+	)
+	if err != nil {
+		return domain.Enrichment{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var skill string
+		if err := rows.Scan(&skill); err != nil {
+			return domain.Enrichment{}, err
+		}
+		enr.Skills = append(enr.Skills, skill)
+	}
+	return enr, rows.Err()
+}
+
+// GetJobEnrichment loads an enrichment by id.
+// Returns ErrNotFound if there is no such row.
+func (s *Store) GetJobEnrichment(ctx context.Context, id int64) (domain.Enrichment, error) {
+	return s.getJobEnrichment(ctx, `WHERE id = ?`, id)
+}
+
+// GetLatestJobEnrichment loads the current enrichment of a job.
+// Returns ErrNotFound if the job was never enriched.
+func (s *Store) GetLatestJobEnrichment(ctx context.Context, jobID int64) (domain.Enrichment, error) {
+	return s.getJobEnrichment(ctx, `WHERE job_id = ? ORDER BY id DESC LIMIT 1`, jobID)
+}
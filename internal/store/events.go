@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"jobflow.local/internal/domain"
+)
+
+// recordApplicationChanges writes one application_events row for each of
+// stage and outcome that differs between before and after.
+func recordApplicationChanges(ctx context.Context, tx *sql.Tx, appID int64, before, after domain.Application, source string) error {
+	changes := []struct {
+		field    string
+		old, new string
+	}{
+		{"stage", before.Stage, after.Stage},
+		{"outcome", before.Outcome, after.Outcome},
+	}
+
+	for _, c := range changes {
+		if c.old == c.new {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO application_events (application_id, field, old_value, new_value, source)
+			VALUES (?, ?, ?, ?, ?)`,
+			appID, c.field, c.old, c.new, source,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListApplicationEvents returns the stage/outcome history of an application,
+// oldest first.
+func (s *Store) ListApplicationEvents(ctx context.Context, appID int64) ([]domain.ApplicationEvent, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT id, application_id, field, COALESCE(old_value, ''), COALESCE(new_value, ''), source, created_at
+		FROM application_events
+		WHERE application_id = ?
+		ORDER BY id`,
+		appID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.ApplicationEvent
+	for rows.Next() {
+		var ev domain.ApplicationEvent
+		if err := rows.Scan(
+			&ev.ID,
+			&ev.ApplicationID,
+			&ev.Field,
+			&ev.OldValue,
+			&ev.NewValue,
+			&ev.Source,
+			&ev.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"jobflow.local/internal/domain"
+)
+
+// currentFitScore is the score of the application's job against the current
+// resume, or NULL. Used in ApplicationSortFields and ListApplications.
+const currentFitScore = `(SELECT f.score FROM job_fit_scores f WHERE f.job_id = a.job_id AND f.resume_id = (SELECT MAX(id) FROM resumes))`
+
+// SaveResume stores a new resume, which becomes the current one.
+func (s *Store) SaveResume(ctx context.Context, content string) (domain.Resume, error) {
+	r := domain.Resume{Content: content}
+	err := s.DB.QueryRowContext(ctx, `
+		INSERT INTO resumes (content) VALUES (?)
+		RETURNING id, created_at`,
+		content,
+	).Scan(&r.ID, &r.CreatedAt)
+	return r, err
+}
+
+// GetCurrentResume loads the latest resume.
+// Returns ErrNotFound if none was uploaded.
+func (s *Store) GetCurrentResume(ctx context.Context) (domain.Resume, error) {
+	var r domain.Resume
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT id, content, created_at FROM resumes ORDER BY id DESC LIMIT 1`,
+	).Scan(&r.ID, &r.Content, &r.CreatedAt)
+	if err == sql.ErrNoRows {
+		return domain.Resume{}, ErrNotFound
+	}
+	return r, err
+}
+
+// SaveFitScore stores fs, replacing any earlier score of the same job and
+// resume, and sets fs.ID and fs.CreatedAt.
+func (s *Store) SaveFitScore(ctx context.Context, fs *domain.FitScore) error {
+	var score sql.NullInt64
+	if fs.Error == "" {
+		score = sql.NullInt64{Int64: int64(fs.Score), Valid: true}
+	}
+	matched, err := json.Marshal(nonNil(fs.MatchedSkills))
+	if err != nil {
+		return err
+	}
+	missing, err := json.Marshal(nonNil(fs.MissingSkills))
+	if err != nil {
+		return err
+	}
+	suggestions, err := json.Marshal(nonNil(fs.Suggestions))
+	if err != nil {
+		return err
+	}
+
+	return s.DB.QueryRowContext(ctx, `
+		INSERT INTO job_fit_scores (job_id, resume_id, score, matched_skills, missing_skills, suggestions, model, prompt_version, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(job_id, resume_id) DO UPDATE SET
+			score = excluded.score,
+			matched_skills = excluded.matched_skills,
+			missing_skills = excluded.missing_skills,
+			suggestions = excluded.suggestions,
+			model = excluded.model,
+			prompt_version = excluded.prompt_version,
+			error = excluded.error,
+			created_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at`,
+		fs.JobID,
+		fs.ResumeID,
+		score,
+		string(matched),
+		string(missing),
+		string(suggestions),
+		fs.Model,
+		fs.PromptVersion,
+		sql.NullString{String: fs.Error, Valid: fs.Error != ""},
+	).Scan(&fs.ID, &fs.CreatedAt)
+}
+
+// GetFitScore loads the score of a job against a resume.
+// Returns ErrNotFound if it wasn't scored yet.
+func (s *Store) GetFitScore(ctx context.Context, jobID, resumeID int64) (domain.FitScore, error) {
+	var (
+		fs                            domain.FitScore
+		score                         sql.NullInt64
+		matched, missing, suggestions string
+	)
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT id, job_id, resume_id, score,
+			COALESCE(matched_skills, '[]'), COALESCE(missing_skills, '[]'), COALESCE(suggestions, '[]'),
+			COALESCE(model, ''), COALESCE(prompt_version, ''), COALESCE(error, ''), created_at
+		FROM job_fit_scores
+		WHERE job_id = ? AND resume_id = ?`,
+		jobID, resumeID,
+	).Scan(
+		&fs.ID,
+		&fs.JobID,
+		&fs.ResumeID,
+		&score,
+		&matched,
+		&missing,
+		&suggestions,
+		&fs.Model,
+		&fs.PromptVersion,
+		&fs.Error,
+		&fs.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return domain.FitScore{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.FitScore{}, err
+	}
+
+	fs.Score = int(score.Int64)
+	for _, f := range []struct {
+		raw string
+		dst *[]string
+	}{
+		{matched, &fs.MatchedSkills},
+		{missing, &fs.MissingSkills},
+		{suggestions, &fs.Suggestions},
+	} {
+		if err := json.Unmarshal([]byte(f.raw), f.dst); err != nil {
+			return domain.FitScore{}, err
+		}
+	}
+	return fs, nil
+}
+
+// ListJobsWithoutFit returns up to limit jobs with a description that have
+// no score against the resume yet, oldest first.
+func (s *Store) ListJobsWithoutFit(ctx context.Context, resumeID int64, limit int) ([]domain.Job, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT j.id
+		FROM jobs j
+		WHERE COALESCE(j.description, '') != ''
+		  AND NOT EXISTS (SELECT 1 FROM job_fit_scores f WHERE f.job_id = j.id AND f.resume_id = ?)
+		ORDER BY j.id
+		LIMIT ?`,
+		resumeID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(ids))
+	for _, id := range ids {
+		job, err := s.GetJob(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -1,6 +1,13 @@
 package store
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"jobflow.local/internal/domain"
+)
 
 // SaveNotionPageID stores the Notion page ID for a given application.
 func (s *Store) SaveNotionPageID(ctx context.Context, appID int64, notionPageID string) error {
@@ -10,3 +17,196 @@ func (s *Store) SaveNotionPageID(ctx context.Context, appID int64, notionPageID
 	)
 	return err
 }
+
+// FindNotionPageIDForJob returns the Notion page already linked to any
+// application of the job (the most recent one), or "" if there is none.
+func (s *Store) FindNotionPageIDForJob(ctx context.Context, jobID int64) (string, error) {
+	var pageID string
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT notion_page_id
+		FROM applications
+		WHERE job_id = ? AND COALESCE(notion_page_id, '') != ''
+		ORDER BY id DESC
+		LIMIT 1`,
+		jobID,
+	).Scan(&pageID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return pageID, err
+}
+
+// NotionLink is an application linked to a Notion page.
+type NotionLink struct {
+	ApplicationID int64
+	JobID         int64
+	PageID        string
+}
+
+// ListNotionLinks returns every application that has a Notion page.
+func (s *Store) ListNotionLinks(ctx context.Context) ([]NotionLink, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT id, job_id, notion_page_id
+		FROM applications
+		WHERE COALESCE(notion_page_id, '') != ''
+		ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []NotionLink
+	for rows.Next() {
+		var l NotionLink
+		if err := rows.Scan(&l.ApplicationID, &l.JobID, &l.PageID); err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+// ListUnlinkedApplications returns the applications without a Notion page,
+// joined with their jobs, oldest first.
+func (s *Store) ListUnlinkedApplications(ctx context.Context) ([]ApplicationListItem, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT `+applicationColumns+`,
+			COALESCE(j.external_id, ''),
+			COALESCE(j.title, ''),
+			COALESCE(j.company, ''),
+			COALESCE(j.url, '')
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		WHERE COALESCE(a.notion_page_id, '') = ''
+		ORDER BY a.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []ApplicationListItem
+	for rows.Next() {
+		var it ApplicationListItem
+		if err := scanApplication(rows, &it.Application,
+			&it.Job.ExternalID,
+			&it.Job.Title,
+			&it.Job.Company,
+			&it.Job.URL,
+		); err != nil {
+			return nil, err
+		}
+		it.Job.ID = it.Application.JobID
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// ClearNotionPageID unlinks every application from a Notion page and
+// returns how many were linked to it.
+func (s *Store) ClearNotionPageID(ctx context.Context, pageID string) (int64, error) {
+	res, err := s.DB.ExecContext(ctx,
+		`UPDATE applications SET notion_page_id = NULL WHERE notion_page_id = ?`,
+		pageID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// ImportNotionApplication inserts an application read from an existing
+// Notion page, already linked to it. The job is matched on external_id and
+// left untouched if it exists; otherwise it is inserted. It reports false,
+// changing nothing, when an application is already linked to the page.
+func (s *Store) ImportNotionApplication(ctx context.Context, job *domain.Job, app *domain.Application) (bool, error) {
+	if app.NotionPageID == nil {
+		return false, errors.New("import: application has no notion page id")
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM applications
+			WHERE REPLACE(notion_page_id, '-', '') = REPLACE(?, '-', '')
+		)`,
+		*app.NotionPageID,
+	).Scan(&exists)
+	if err != nil || exists {
+		return false, err
+	}
+
+	err = tx.QueryRowContext(ctx, `SELECT id FROM jobs WHERE external_id = ?`, job.ExternalID).Scan(&job.ID)
+	if err == sql.ErrNoRows {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO jobs (external_id, title, company, location, url, work_mode, salary, description)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			job.ExternalID,
+			job.Title,
+			job.Company,
+			job.Location,
+			job.URL,
+			job.WorkMode,
+			job.Salary,
+			job.Description,
+		)
+		if err != nil {
+			return false, err
+		}
+		if job.ID, err = res.LastInsertId(); err != nil {
+			return false, err
+		}
+		if err := setJobPlace(ctx, tx, *job); err != nil {
+			return false, err
+		}
+		if err := s.setJobSalary(ctx, tx, job); err != nil {
+			return false, err
+		}
+	} else if err != nil {
+		return false, err
+	}
+
+	// Keep the page's creation time so imported rows sort with the rest.
+	createdAt := time.Now().UTC()
+	if !app.CreatedAt.IsZero() {
+		createdAt = app.CreatedAt.UTC()
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO applications (job_id, status, outcome, applied_on, interview_time, notes, notion_page_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		job.ID,
+		app.Stage,
+		app.Outcome,
+		timeArg(app.AppliedOn),
+		timeArg(app.InterviewTime),
+		app.Notes,
+		*app.NotionPageID,
+		createdAt.Format(sqliteTimeLayout),
+	)
+	if err != nil {
+		return false, err
+	}
+	if app.ID, err = res.LastInsertId(); err != nil {
+		return false, err
+	}
+	app.JobID = job.ID
+
+	if err := recordApplicationChanges(ctx, tx, app.ID, domain.Application{}, *app, domain.SourceNotion); err != nil {
+		return false, err
+	}
+
+	committed = true
+	return true, tx.Commit()
+}
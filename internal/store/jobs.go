@@ -9,8 +9,11 @@ import (
 
 // UpsertJobAndApplication:
 // - If ExternalID is present, update or insert the job
+// - Store its normalized place and parse its salary into the structured columns
 // - Always insert a new application row
-func (s *Store) UpsertJobAndApplication(ctx context.Context, job *domain.Job, app *domain.Application) error {
+// - Record the initial stage/outcome in application_events, tagged with source
+// - Queue the Notion page write (enr is the page body) unless already linked
+func (s *Store) UpsertJobAndApplication(ctx context.Context, job *domain.Job, app *domain.Application, enr *domain.Enrichment, source string) error {
 	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -101,17 +104,24 @@ func (s *Store) UpsertJobAndApplication(ctx context.Context, job *domain.Job, ap
 		job.ID = jobID
 	}
 
+	if err := setJobPlace(ctx, tx, *job); err != nil {
+		return err
+	}
+	if err := s.setJobSalary(ctx, tx, job); err != nil {
+		return err
+	}
+
 	// --- 2) Insert Application row ---
 
 	res, err := tx.ExecContext(ctx, `
-		INSERT INTO applications (job_id, status, outcome, applied_on, interview_time, notes, notion_page_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		INSERT INTO applications (job_id, status, outcome, applied_on, interview_time, notes, notion_page_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
 		job.ID,
 		// We store Stage into "status" column:
 		app.Stage,
 		app.Outcome,
-		app.AppliedOn,
-		app.InterviewTime,
+		timeArg(app.AppliedOn),
+		timeArg(app.InterviewTime),
 		app.Notes,
 		app.NotionPageID,
 	)
@@ -125,6 +135,183 @@ func (s *Store) UpsertJobAndApplication(ctx context.Context, job *domain.Job, ap
 	app.ID = appID
 	app.JobID = job.ID
 
+	// --- 3) Log the initial stage/outcome ---
+
+	if err := recordApplicationChanges(ctx, tx, app.ID, domain.Application{}, *app, source); err != nil {
+		return err
+	}
+
+	// --- 4) Queue the Notion write ---
+
+	if app.NotionPageID == nil {
+		if err := enqueueNotionWrite(ctx, tx, app.ID, OutboxOpPage, enr); err != nil {
+			return err
+		}
+	}
+
 	committed = true
 	return tx.Commit()
 }
+
+// jobColumns are the columns scanJob reads, from jobs aliased j.
+const jobColumns = `j.id, j.external_id, j.title, j.company, j.location, j.url, j.work_mode, j.salary, j.description, j.created_at,
+	COALESCE(j.location_city, ''), COALESCE(j.location_region, ''), COALESCE(j.location_country, ''), COALESCE(j.remote_scope, ''),
+	` + jobSalaryColumns
+
+func scanJob(row interface{ Scan(...any) error }, job *domain.Job) error {
+	var (
+		externalID  sql.NullString
+		title       sql.NullString
+		company     sql.NullString
+		location    sql.NullString
+		url         sql.NullString
+		workMode    sql.NullString
+		salary      sql.NullString
+		description sql.NullString
+		createdAt   sql.NullTime
+		ss          salaryScan
+	)
+
+	err := row.Scan(append([]any{
+		&job.ID,
+		&externalID,
+		&title,
+		&company,
+		&location,
+		&url,
+		&workMode,
+		&salary,
+		&description,
+		&createdAt,
+		&job.Place.City,
+		&job.Place.Region,
+		&job.Place.Country,
+		&job.Place.RemoteScope,
+	}, ss.dest()...)...)
+	if err != nil {
+		return err
+	}
+
+	job.ExternalID = externalID.String
+	job.Title = title.String
+	job.Company = company.String
+	job.Location = location.String
+	job.URL = url.String
+	job.WorkMode = workMode.String
+	job.Salary = salary.String
+	job.Description = description.String
+	job.CreatedAt = createdAt.Time
+	job.SalaryRange = ss.value()
+	return nil
+}
+
+// GetJob loads a single job by id.
+// Returns ErrNotFound if there is no such row.
+func (s *Store) GetJob(ctx context.Context, id int64) (domain.Job, error) {
+	var job domain.Job
+	err := scanJob(s.DB.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs j WHERE j.id = ?`, id), &job)
+	if err == sql.ErrNoRows {
+		return domain.Job{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Job{}, err
+	}
+	return job, nil
+}
+
+// ListJobs returns every job, oldest first.
+func (s *Store) ListJobs(ctx context.Context) ([]domain.Job, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT `+jobColumns+` FROM jobs j ORDER BY j.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		var job domain.Job
+		if err := scanJob(rows, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// setJobPlace stores job.Place in the normalized location columns.
+func setJobPlace(ctx context.Context, tx *sql.Tx, job domain.Job) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE jobs
+		SET location_city = ?, location_region = ?, location_country = ?, remote_scope = ?
+		WHERE id = ?`,
+		job.Place.City,
+		job.Place.Region,
+		job.Place.Country,
+		job.Place.RemoteScope,
+		job.ID,
+	)
+	return err
+}
+
+// SaveNormalizedJob writes a job's normalized work mode and place, and
+// queues an update of the Notion pages of its linked applications so the
+// tracker gets the cleaned values. It returns how many updates it queued.
+func (s *Store) SaveNormalizedJob(ctx context.Context, job domain.Job) (queued int, err error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, `UPDATE jobs SET work_mode = ? WHERE id = ?`, job.WorkMode, job.ID)
+	if err != nil {
+		return 0, err
+	}
+	if err := expectOneRow(res); err != nil {
+		return 0, err
+	}
+	if err := setJobPlace(ctx, tx, job); err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id FROM applications
+		WHERE job_id = ? AND COALESCE(notion_page_id, '') != ''`,
+		job.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	var appIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		appIDs = append(appIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, id := range appIDs {
+		added, err := enqueueNotionPage(ctx, tx, id)
+		if err != nil {
+			return 0, err
+		}
+		if added {
+			queued++
+		}
+	}
+
+	committed = true
+	return queued, tx.Commit()
+}
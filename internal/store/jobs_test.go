@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"jobflow.local/internal/domain"
+)
+
+func TestJobRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+
+	in := domain.Job{
+		ExternalID:  "jobs-1",
+		Title:       "Backend Engineer",
+		Company:     "Acme",
+		Location:    "Toronto, ON (Hybrid)",
+		URL:         "https://example.com/jobs/1",
+		Salary:      "CA$90K/yr - CA$110K/yr",
+		Description: "Build things.",
+	}
+	domain.NormalizeJob(&in)
+	saved, _ := saveTestJob(t, st, in)
+
+	got, err := st.GetJob(ctx, saved.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != in.Title || got.Company != in.Company || got.Location != in.Location ||
+		got.URL != in.URL || got.Description != in.Description || got.WorkMode != domain.WorkModeHybrid {
+		t.Errorf("GetJob = %+v, want the saved fields of %+v", got, in)
+	}
+	if want := (domain.Place{City: "Toronto", Region: "Ontario", Country: "CA"}); got.Place != want {
+		t.Errorf("place = %+v, want %+v", got.Place, want)
+	}
+	r := got.SalaryRange
+	if r == nil {
+		t.Fatal("salary range not stored")
+	}
+	if r.Min != 90_000 || r.Max != 110_000 || r.Currency != "CAD" || r.Period != domain.SalaryPerYear ||
+		r.AnnualMin != 90_000 || r.AnnualMax != 110_000 || r.Inferred {
+		t.Errorf("salary range = %+v", *r)
+	}
+
+	jobs, err := st.ListJobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != saved.ID || jobs[0].Place != got.Place {
+		t.Errorf("ListJobs = %+v", jobs)
+	}
+}
+
+func TestSaveNormalizedJobQueuesLinkedPages(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	job, _ := saveTestJob(t, st, domain.Job{ExternalID: "jobs-2", Title: "Engineer", Location: "Remote - US", WorkMode: "Flexible"})
+
+	// Not linked to a Notion page yet: nothing to queue.
+	domain.NormalizeJob(&job)
+	queued, err := st.SaveNormalizedJob(ctx, job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued != 0 {
+		t.Errorf("queued %d page updates for an unlinked job", queued)
+	}
+
+	got, err := st.GetJob(ctx, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.WorkMode != domain.WorkModeRemote || got.Place.RemoteScope != "US" {
+		t.Errorf("normalized job = %q %+v", got.WorkMode, got.Place)
+	}
+}
@@ -0,0 +1,490 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// migration is one numbered schema step. Versions must be strictly
+// increasing; a step is never edited once it has shipped — add a new one.
+type migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// migrations is the ordered list of schema steps. Step 1 is the original
+// schema, written with IF NOT EXISTS so databases created before versioning
+// existed are adopted as-is.
+var migrations = []migration{
+	{
+		Version: 1,
+		Name:    "initial schema",
+		SQL: `
+CREATE TABLE IF NOT EXISTS jobs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	external_id TEXT UNIQUE,
+	title TEXT,
+	company TEXT,
+	location TEXT,
+	url TEXT,
+	work_mode TEXT,
+	salary TEXT,
+	description TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS applications (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	job_id INTEGER NOT NULL,
+	status TEXT,
+	outcome TEXT,
+	applied_on TIMESTAMP NULL,
+	interview_time TIMESTAMP NULL,
+	notes TEXT,
+	notion_page_id TEXT,
+	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS contacts (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	job_id INTEGER NOT NULL,
+	name TEXT,
+	email TEXT,
+	role TEXT,
+	notes TEXT,
+	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+`,
+	},
+	{
+		Version: 2,
+		Name:    "application events",
+		SQL: `
+CREATE TABLE application_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	application_id INTEGER NOT NULL,
+	field TEXT NOT NULL,
+	old_value TEXT,
+	new_value TEXT,
+	source TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY(application_id) REFERENCES applications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_application_events_app ON application_events(application_id, id);
+`,
+	},
+	{
+		Version: 3,
+		Name:    "jobs full-text index",
+		SQL: `
+CREATE VIRTUAL TABLE jobs_fts USING fts5(
+	title,
+	company,
+	location,
+	description,
+	content='jobs',
+	content_rowid='id'
+);
+
+CREATE TRIGGER jobs_fts_ai AFTER INSERT ON jobs BEGIN
+	INSERT INTO jobs_fts(rowid, title, company, location, description)
+	VALUES (new.id, new.title, new.company, new.location, new.description);
+END;
+
+CREATE TRIGGER jobs_fts_ad AFTER DELETE ON jobs BEGIN
+	INSERT INTO jobs_fts(jobs_fts, rowid, title, company, location, description)
+	VALUES ('delete', old.id, old.title, old.company, old.location, old.description);
+END;
+
+CREATE TRIGGER jobs_fts_au AFTER UPDATE ON jobs BEGIN
+	INSERT INTO jobs_fts(jobs_fts, rowid, title, company, location, description)
+	VALUES ('delete', old.id, old.title, old.company, old.location, old.description);
+	INSERT INTO jobs_fts(rowid, title, company, location, description)
+	VALUES (new.id, new.title, new.company, new.location, new.description);
+END;
+
+INSERT INTO jobs_fts(jobs_fts) VALUES ('rebuild');
+`,
+	},
+	{
+		// SQLite can't ADD COLUMN with a CURRENT_TIMESTAMP default, so inserts
+		// set created_at explicitly; existing rows inherit their job's date.
+		Version: 4,
+		Name:    "applications created_at",
+		SQL: `
+ALTER TABLE applications ADD COLUMN created_at TIMESTAMP NULL;
+
+UPDATE applications
+SET created_at = (SELECT created_at FROM jobs WHERE jobs.id = applications.job_id);
+
+CREATE INDEX idx_applications_created_at ON applications(created_at, id);
+CREATE INDEX idx_applications_job_id ON applications(job_id);
+`,
+	},
+	{
+		Version: 5,
+		Name:    "sync state",
+		SQL: `
+CREATE TABLE sync_state (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_applications_notion_page_id ON applications(notion_page_id);
+`,
+	},
+	{
+		Version: 6,
+		Name:    "notion outbox",
+		SQL: `
+CREATE TABLE notion_outbox (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	application_id INTEGER NOT NULL,
+	op TEXT NOT NULL,
+	payload TEXT,
+	status TEXT NOT NULL DEFAULT 'pending',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT,
+	next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY(application_id) REFERENCES applications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_notion_outbox_due ON notion_outbox(status, next_attempt_at);
+CREATE INDEX idx_notion_outbox_app ON notion_outbox(application_id);
+`,
+	},
+	{
+		Version: 7,
+		Name:    "enrichment queue",
+		SQL: `
+CREATE TABLE enrichment_queue (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	application_id INTEGER NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT,
+	result TEXT,
+	next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY(application_id) REFERENCES applications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_enrichment_queue_due ON enrichment_queue(status, next_attempt_at);
+CREATE INDEX idx_enrichment_queue_app ON enrichment_queue(application_id);
+`,
+	},
+	{
+		Version: 8,
+		Name:    "job enrichments",
+		SQL: `
+CREATE TABLE job_enrichments (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	job_id INTEGER NOT NULL,
+	summary TEXT,
+	tailored_note TEXT,
+	snippet TEXT,
+	model TEXT,
+	prompt_version TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_job_enrichments_job ON job_enrichments(job_id, id);
+
+CREATE TABLE job_skills (
+	enrichment_id INTEGER NOT NULL,
+	job_id INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	skill TEXT NOT NULL COLLATE NOCASE,
+	PRIMARY KEY (enrichment_id, position),
+	FOREIGN KEY(enrichment_id) REFERENCES job_enrichments(id) ON DELETE CASCADE,
+	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_job_skills_skill ON job_skills(skill);
+
+-- The queue now points at the stored enrichment instead of a JSON copy.
+ALTER TABLE enrichment_queue DROP COLUMN result;
+ALTER TABLE enrichment_queue ADD COLUMN enrichment_id INTEGER REFERENCES job_enrichments(id);
+`,
+	},
+	{
+		Version: 9,
+		Name:    "resumes and fit scores",
+		SQL: `
+CREATE TABLE resumes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	content TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One score per job and resume; error is set (and score NULL) when the
+-- model's reply could not be used.
+CREATE TABLE job_fit_scores (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	job_id INTEGER NOT NULL,
+	resume_id INTEGER NOT NULL,
+	score INTEGER,
+	matched_skills TEXT,
+	missing_skills TEXT,
+	suggestions TEXT,
+	model TEXT,
+	prompt_version TEXT,
+	error TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(job_id, resume_id),
+	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE,
+	FOREIGN KEY(resume_id) REFERENCES resumes(id) ON DELETE CASCADE
+);
+`,
+	},
+	{
+		Version: 10,
+		Name:    "drafts",
+		SQL: `
+-- Generated cover letters and outreach messages. Every generation is a new
+-- version; contact_id is set for outreach messages.
+CREATE TABLE drafts (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	application_id INTEGER NOT NULL,
+	contact_id INTEGER,
+	kind TEXT NOT NULL,
+	version INTEGER NOT NULL,
+	tone TEXT,
+	content TEXT NOT NULL,
+	model TEXT,
+	prompt_version TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY(application_id) REFERENCES applications(id) ON DELETE CASCADE,
+	FOREIGN KEY(contact_id) REFERENCES contacts(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_drafts_version ON drafts(application_id, kind, COALESCE(contact_id, 0), version);
+`,
+	},
+	{
+		Version: 11,
+		Name:    "contact review",
+		SQL: `
+-- Contacts extracted from job descriptions wait as 'suggested' until
+-- accepted or discarded. Discarded rows are kept so they aren't suggested
+-- again.
+ALTER TABLE contacts ADD COLUMN source TEXT NOT NULL DEFAULT 'manual';
+ALTER TABLE contacts ADD COLUMN status TEXT NOT NULL DEFAULT 'accepted';
+ALTER TABLE contacts ADD COLUMN confidence REAL NOT NULL DEFAULT 1;
+
+CREATE INDEX idx_contacts_status ON contacts(status);
+`,
+	},
+	{
+		Version: 12,
+		Name:    "structured salary",
+		SQL: `
+-- Parsed from jobs.salary (or inferred from the description when it is
+-- empty); the annual columns are what sorting and filtering use.
+ALTER TABLE jobs ADD COLUMN salary_min REAL;
+ALTER TABLE jobs ADD COLUMN salary_max REAL;
+ALTER TABLE jobs ADD COLUMN salary_currency TEXT;
+ALTER TABLE jobs ADD COLUMN salary_period TEXT;
+ALTER TABLE jobs ADD COLUMN salary_annual_min REAL;
+ALTER TABLE jobs ADD COLUMN salary_annual_max REAL;
+ALTER TABLE jobs ADD COLUMN salary_inferred INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_jobs_salary_annual_max ON jobs(salary_annual_max);
+`,
+	},
+	{
+		Version: 13,
+		Name:    "normalized location",
+		SQL: `
+-- jobs.location split by domain.NormalizeLocation; jobs.location keeps the
+-- scraped text.
+ALTER TABLE jobs ADD COLUMN location_city TEXT;
+ALTER TABLE jobs ADD COLUMN location_region TEXT;
+ALTER TABLE jobs ADD COLUMN location_country TEXT;
+ALTER TABLE jobs ADD COLUMN remote_scope TEXT;
+
+CREATE INDEX idx_jobs_location_country ON jobs(location_country);
+`,
+	},
+	{
+		Version: 14,
+		Name:    "job embeddings",
+		SQL: `
+-- One vector per job and embedding model, as little-endian float32s.
+-- content_hash identifies the text it was computed from.
+CREATE TABLE job_embeddings (
+	job_id INTEGER NOT NULL,
+	model TEXT NOT NULL,
+	dims INTEGER NOT NULL,
+	vector BLOB NOT NULL,
+	content_hash TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY(job_id, model),
+	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+`,
+	},
+	{
+		Version: 15,
+		Name:    "drafts deleted with their contact",
+		SQL: `
+-- Outreach drafts went to contact_id NULL when their contact was deleted,
+-- which collides in idx_drafts_version once two contacts had the same
+-- version. They now go with the contact. SQLite can't change a foreign key
+-- in place, so the table is rebuilt.
+CREATE TABLE drafts_rebuilt (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	application_id INTEGER NOT NULL,
+	contact_id INTEGER,
+	kind TEXT NOT NULL,
+	version INTEGER NOT NULL,
+	tone TEXT,
+	content TEXT NOT NULL,
+	model TEXT,
+	prompt_version TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY(application_id) REFERENCES applications(id) ON DELETE CASCADE,
+	FOREIGN KEY(contact_id) REFERENCES contacts(id) ON DELETE CASCADE
+);
+
+INSERT INTO drafts_rebuilt (id, application_id, contact_id, kind, version, tone, content, model, prompt_version, created_at)
+SELECT id, application_id, contact_id, kind, version, tone, content, model, prompt_version, created_at FROM drafts;
+
+DROP TABLE drafts;
+ALTER TABLE drafts_rebuilt RENAME TO drafts;
+
+CREATE UNIQUE INDEX idx_drafts_version ON drafts(application_id, kind, COALESCE(contact_id, 0), version);
+`,
+	},
+	{
+		Version: 16,
+		Name:    "job embedding failures",
+		SQL: `
+-- The last failed attempt to embed a job under a model, so the background
+-- pass skips the job until next_attempt_at instead of retrying it on every
+-- pass. Cleared when a vector is saved.
+CREATE TABLE job_embedding_failures (
+	job_id INTEGER NOT NULL,
+	model TEXT NOT NULL,
+	error TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 1,
+	next_attempt_at TIMESTAMP NOT NULL,
+	PRIMARY KEY(job_id, model),
+	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+`,
+	},
+}
+
+// MigrationStatus describes one known migration and whether it has run.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+func (m MigrationStatus) Applied() bool { return m.AppliedAt != nil }
+
+func (s *Store) ensureMigrationsTable(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`)
+	return err
+}
+
+// Migrations reports every known migration, in order, with the time it was
+// applied (nil when still pending).
+func (s *Store) Migrations(ctx context.Context) ([]MigrationStatus, error) {
+	if err := s.ensureMigrationsTable(ctx); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	rows, err := s.DB.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var (
+			version int
+			at      time.Time
+		)
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		st := MigrationStatus{Version: m.Version, Name: m.Name}
+		if at, ok := applied[m.Version]; ok {
+			st.AppliedAt = &at
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+// Migrate applies every pending migration in version order. Each step runs
+// in its own transaction together with its schema_migrations record, so a
+// failing step leaves the database at the previous version.
+func (s *Store) Migrate(ctx context.Context) error {
+	statuses, err := s.Migrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, st := range statuses {
+		if st.Applied() {
+			continue
+		}
+		if err := s.applyMigration(ctx, migrations[i]); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", st.Version, st.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) applyMigration(ctx context.Context, m migration) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`,
+		m.Version, m.Name,
+	); err != nil {
+		return err
+	}
+
+	committed = true
+	return tx.Commit()
+}
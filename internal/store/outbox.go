@@ -0,0 +1,248 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"jobflow.local/internal/domain"
+)
+
+// Notion outbox operations.
+const (
+	OutboxOpPage        = "page"        // create or fully update the job's page
+	OutboxOpApplication = "application" // push stage/outcome/notes/interview only
+	OutboxOpEnrichment  = "enrichment"  // append the AI enrichment to the page body
+)
+
+// Notion outbox statuses. Delivered rows are deleted.
+const (
+	OutboxPending    = "pending"
+	OutboxProcessing = "processing"
+	OutboxDead       = "dead"
+)
+
+// OutboxItem is one Notion write waiting to be delivered.
+type OutboxItem struct {
+	ID            int64
+	ApplicationID int64
+	Op            string
+	Enrichment    *domain.Enrichment // page body for OutboxOpPage creates and OutboxOpEnrichment
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+const outboxColumns = `id, application_id, op, COALESCE(payload, ''), status, attempts, COALESCE(last_error, ''), next_attempt_at, created_at, updated_at`
+
+func scanOutboxItem(row interface{ Scan(...any) error }, it *OutboxItem) error {
+	var payload string
+	if err := row.Scan(
+		&it.ID,
+		&it.ApplicationID,
+		&it.Op,
+		&payload,
+		&it.Status,
+		&it.Attempts,
+		&it.LastError,
+		&it.NextAttemptAt,
+		&it.CreatedAt,
+		&it.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	if payload != "" {
+		var enr domain.Enrichment
+		if err := json.Unmarshal([]byte(payload), &enr); err != nil {
+			return err
+		}
+		it.Enrichment = &enr
+	}
+	return nil
+}
+
+// enqueueNotionWrite adds an outbox row inside the caller's transaction, so
+// the write to Notion is recorded if and only if the SQLite change commits.
+func enqueueNotionWrite(ctx context.Context, tx *sql.Tx, appID int64, op string, enr *domain.Enrichment) error {
+	var payload any
+	if enr != nil {
+		b, err := json.Marshal(enr)
+		if err != nil {
+			return err
+		}
+		payload = string(b)
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO notion_outbox (application_id, op, payload)
+		VALUES (?, ?, ?)`,
+		appID, op, payload,
+	)
+	return err
+}
+
+// EnqueueNotionPage queues the creation (or full update) of an
+// application's Notion page, unless one is already queued. It reports
+// whether a new item was added.
+func (s *Store) EnqueueNotionPage(ctx context.Context, appID int64) (bool, error) {
+	return enqueueNotionPage(ctx, s.DB, appID)
+}
+
+// enqueueNotionPage is EnqueueNotionPage on db, which may be a transaction.
+func enqueueNotionPage(ctx context.Context, db interface {
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+}, appID int64) (bool, error) {
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO notion_outbox (application_id, op)
+		SELECT ?, ?
+		WHERE NOT EXISTS (
+			SELECT 1 FROM notion_outbox
+			WHERE application_id = ? AND op = ? AND status IN (?, ?)
+		)`,
+		appID, OutboxOpPage, appID, OutboxOpPage, OutboxPending, OutboxProcessing,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (s *Store) queryOutbox(ctx context.Context, query string, args ...any) ([]OutboxItem, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT `+outboxColumns+` FROM notion_outbox `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []OutboxItem
+	for rows.Next() {
+		var it OutboxItem
+		if err := scanOutboxItem(rows, &it); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// ListDueOutboxItems returns pending items whose next attempt is due,
+// oldest first.
+func (s *Store) ListDueOutboxItems(ctx context.Context, limit int) ([]OutboxItem, error) {
+	return s.queryOutbox(ctx,
+		`WHERE status = ? AND next_attempt_at <= CURRENT_TIMESTAMP ORDER BY id LIMIT ?`,
+		OutboxPending, limit,
+	)
+}
+
+// ListPendingOutboxItemsForApplication returns the pending items of one
+// application regardless of their next attempt time, oldest first.
+func (s *Store) ListPendingOutboxItemsForApplication(ctx context.Context, appID int64) ([]OutboxItem, error) {
+	return s.queryOutbox(ctx,
+		`WHERE status = ? AND application_id = ? ORDER BY id`,
+		OutboxPending, appID,
+	)
+}
+
+// HasQueuedNotionWrite reports whether an application has a pending or
+// processing outbox item with one of ops, i.e. local changes Notion hasn't
+// received yet.
+func (s *Store) HasQueuedNotionWrite(ctx context.Context, appID int64, ops ...string) (bool, error) {
+	if len(ops) == 0 {
+		return false, nil
+	}
+	args := []any{appID, OutboxPending, OutboxProcessing}
+	for _, op := range ops {
+		args = append(args, op)
+	}
+	var exists bool
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM notion_outbox
+			WHERE application_id = ? AND status IN (?, ?) AND op IN (?`+strings.Repeat(", ?", len(ops)-1)+`)
+		)`,
+		args...,
+	).Scan(&exists)
+	return exists, err
+}
+
+// ListOutboxItems returns the items with the given status, newest first.
+func (s *Store) ListOutboxItems(ctx context.Context, status string, limit int) ([]OutboxItem, error) {
+	return s.queryOutbox(ctx,
+		`WHERE status = ? ORDER BY id DESC LIMIT ?`,
+		status, limit,
+	)
+}
+
+// ClaimOutboxItem marks a pending item as processing. It reports false when
+// another worker got there first.
+func (s *Store) ClaimOutboxItem(ctx context.Context, id int64) (bool, error) {
+	res, err := s.DB.ExecContext(ctx, `
+		UPDATE notion_outbox
+		SET status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = ?`,
+		OutboxProcessing, id, OutboxPending,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// CompleteOutboxItem removes a delivered item.
+func (s *Store) CompleteOutboxItem(ctx context.Context, id int64) error {
+	_, err := s.DB.ExecContext(ctx, `DELETE FROM notion_outbox WHERE id = ?`, id)
+	return err
+}
+
+// FailOutboxItem records a failed attempt. With a retry time the item goes
+// back to pending; with nil it is dead-lettered.
+func (s *Store) FailOutboxItem(ctx context.Context, id int64, errMsg string, retryAt *time.Time) error {
+	status, next := OutboxDead, any(nil)
+	if retryAt != nil {
+		status, next = OutboxPending, retryAt.UTC().Format(sqliteTimeLayout)
+	}
+
+	_, err := s.DB.ExecContext(ctx, `
+		UPDATE notion_outbox
+		SET status = ?,
+			attempts = attempts + 1,
+			last_error = ?,
+			next_attempt_at = COALESCE(?, next_attempt_at),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`,
+		status, errMsg, next, id,
+	)
+	return err
+}
+
+// RetryOutboxItem puts a dead item back in the queue with a fresh attempt
+// budget. Returns ErrNotFound if there is no dead item with that id.
+func (s *Store) RetryOutboxItem(ctx context.Context, id int64) error {
+	res, err := s.DB.ExecContext(ctx, `
+		UPDATE notion_outbox
+		SET status = ?, attempts = 0, next_attempt_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = ?`,
+		OutboxPending, id, OutboxDead,
+	)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res)
+}
+
+// ResetStaleOutboxItems returns items left in processing (e.g. by a crash)
+// to pending. Call it before any worker starts.
+func (s *Store) ResetStaleOutboxItems(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx,
+		`UPDATE notion_outbox SET status = ? WHERE status = ?`,
+		OutboxPending, OutboxProcessing,
+	)
+	return err
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"jobflow.local/internal/domain"
+)
+
+func TestOutboxItemLifecycle(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t)
+	_, app := saveTestJob(t, st, domain.Job{ExternalID: "outbox-1", Title: "Engineer"})
+
+	queued := func() bool {
+		t.Helper()
+		ok, err := st.HasQueuedNotionWrite(ctx, app.ID, OutboxOpApplication, OutboxOpPage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok
+	}
+
+	// Saving a new application queues its page.
+	items, err := st.ListPendingOutboxItemsForApplication(ctx, app.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Op != OutboxOpPage {
+		t.Fatalf("queued items = %+v, want one %s item", items, OutboxOpPage)
+	}
+	if !queued() {
+		t.Fatal("page write not reported as queued")
+	}
+	if added, err := st.EnqueueNotionPage(ctx, app.ID); err != nil || added {
+		t.Fatalf("second EnqueueNotionPage = %v, %v; want no new item", added, err)
+	}
+
+	id := items[0].ID
+	if ok, err := st.ClaimOutboxItem(ctx, id); err != nil || !ok {
+		t.Fatalf("claim = %v, %v", ok, err)
+	}
+	if ok, err := st.ClaimOutboxItem(ctx, id); err != nil || ok {
+		t.Fatalf("second claim = %v, %v; want false", ok, err)
+	}
+	if !queued() {
+		t.Fatal("processing item not reported as queued")
+	}
+
+	if err := st.FailOutboxItem(ctx, id, "notion down", nil); err != nil {
+		t.Fatal(err)
+	}
+	if queued() {
+		t.Fatal("dead item reported as queued")
+	}
+	dead, err := st.ListOutboxItems(ctx, OutboxDead, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dead) != 1 || dead[0].Attempts != 1 || dead[0].LastError != "notion down" {
+		t.Fatalf("dead items = %+v", dead)
+	}
+
+	if err := st.RetryOutboxItem(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if !queued() {
+		t.Fatal("retried item not reported as queued")
+	}
+	if ok, err := st.ClaimOutboxItem(ctx, id); err != nil || !ok {
+		t.Fatalf("claim after retry = %v, %v", ok, err)
+	}
+	if err := st.CompleteOutboxItem(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if queued() {
+		t.Fatal("delivered item still reported as queued")
+	}
+	if ok, err := st.HasQueuedNotionWrite(ctx, app.ID); err != nil || ok {
+		t.Fatalf("HasQueuedNotionWrite with no ops = %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,183 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"jobflow.local/internal/domain"
+	"jobflow.local/internal/salary"
+)
+
+// jobSalaryColumns are the structured salary columns of a jobs row aliased
+// j, in the order salaryScan.dest expects.
+const jobSalaryColumns = `j.salary_min, j.salary_max, j.salary_currency, j.salary_period, j.salary_annual_min, j.salary_annual_max, j.salary_inferred`
+
+// salaryScan receives jobSalaryColumns.
+type salaryScan struct {
+	min, max, annualMin, annualMax sql.NullFloat64
+	currency, period               sql.NullString
+	inferred                       bool
+}
+
+func (ss *salaryScan) dest() []any {
+	return []any{&ss.min, &ss.max, &ss.currency, &ss.period, &ss.annualMin, &ss.annualMax, &ss.inferred}
+}
+
+// value returns the scanned range, or nil when the job has none.
+func (ss *salaryScan) value() *domain.SalaryRange {
+	if !ss.min.Valid {
+		return nil
+	}
+	return &domain.SalaryRange{
+		Min:       ss.min.Float64,
+		Max:       ss.max.Float64,
+		Currency:  ss.currency.String,
+		Period:    ss.period.String,
+		AnnualMin: ss.annualMin.Float64,
+		AnnualMax: ss.annualMax.Float64,
+		Inferred:  ss.inferred,
+	}
+}
+
+// setJobSalary parses job.Salary into the structured columns and sets
+// job.SalaryRange. A salary that doesn't parse clears them, unless the range
+// was inferred from the description.
+func (s *Store) setJobSalary(ctx context.Context, tx *sql.Tx, job *domain.Job) error {
+	r, ok := salary.Parse(job.Salary)
+	if !ok {
+		job.SalaryRange = nil
+		_, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET salary_min = NULL, salary_max = NULL, salary_currency = NULL, salary_period = NULL,
+				salary_annual_min = NULL, salary_annual_max = NULL
+			WHERE id = ? AND salary_inferred = 0`,
+			job.ID,
+		)
+		return err
+	}
+
+	salary.Annualize(&r, s.HoursPerYear)
+	job.SalaryRange = &r
+	return writeSalaryRange(ctx, tx, job.ID, r)
+}
+
+// writeSalaryRange stores r in a job's structured salary columns.
+func writeSalaryRange(ctx context.Context, tx *sql.Tx, jobID int64, r domain.SalaryRange) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE jobs
+		SET salary_min = ?, salary_max = ?, salary_currency = ?, salary_period = ?,
+			salary_annual_min = ?, salary_annual_max = ?, salary_inferred = ?
+		WHERE id = ?`,
+		r.Min,
+		r.Max,
+		r.Currency,
+		r.Period,
+		r.AnnualMin,
+		r.AnnualMax,
+		r.Inferred,
+		jobID,
+	)
+	return err
+}
+
+// SaveInferredSalary stores a salary the LLM read from the description,
+// marked as inferred. It only applies to jobs whose salary field is empty;
+// applied reports whether it did.
+func (s *Store) SaveInferredSalary(ctx context.Context, jobID int64, r domain.SalaryRange) (applied bool, err error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var raw string
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(salary, '') FROM jobs WHERE id = ?`, jobID).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return false, ErrNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+	if raw != "" {
+		return false, nil
+	}
+
+	r.Inferred = true
+	salary.Annualize(&r, s.HoursPerYear)
+	if err := writeSalaryRange(ctx, tx, jobID, r); err != nil {
+		return false, err
+	}
+
+	committed = true
+	return true, tx.Commit()
+}
+
+// ReparseSalaries rebuilds the structured salary of every job: salary fields
+// are parsed again and inferred ranges re-annualized, e.g. after the parser
+// or HoursPerYear changed. It returns how many jobs now have a range.
+func (s *Store) ReparseSalaries(ctx context.Context) (int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, `SELECT j.id, COALESCE(j.salary, ''), `+jobSalaryColumns+` FROM jobs j`)
+	if err != nil {
+		return 0, err
+	}
+	var jobs []domain.Job
+	for rows.Next() {
+		var (
+			job domain.Job
+			ss  salaryScan
+		)
+		if err := rows.Scan(append([]any{&job.ID, &job.Salary}, ss.dest()...)...); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		job.SalaryRange = ss.value()
+		jobs = append(jobs, job)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, job := range jobs {
+		if job.Salary == "" && job.SalaryRange != nil && job.SalaryRange.Inferred {
+			r := *job.SalaryRange
+			salary.Annualize(&r, s.HoursPerYear)
+			if err := writeSalaryRange(ctx, tx, job.ID, r); err != nil {
+				return 0, err
+			}
+			n++
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE jobs SET salary_inferred = 0 WHERE id = ?`, job.ID); err != nil {
+			return 0, err
+		}
+		if err := s.setJobSalary(ctx, tx, &job); err != nil {
+			return 0, err
+		}
+		if job.SalaryRange != nil {
+			n++
+		}
+	}
+
+	committed = true
+	return n, tx.Commit()
+}
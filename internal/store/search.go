@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"jobflow.local/internal/domain"
+)
+
+// JobSearchHit is one ranked result of SearchJobs. The highlighted fields are
+// HTML: the job's text escaped, with matched terms wrapped in <mark>…</mark>.
+type JobSearchHit struct {
+	Job domain.Job
+
+	TitleHighlight   string
+	CompanyHighlight string
+	Snippet          string
+	Rank             float64 // bm25: lower is a better match
+}
+
+// FTS5 wraps matches in these private-use characters; markMatches turns them
+// into <mark> tags once the text around them is escaped.
+const (
+	matchStart = "\uE000"
+	matchEnd   = "\uE001"
+)
+
+var matchTags = strings.NewReplacer(matchStart, "<mark>", matchEnd, "</mark>")
+
+// markMatches escapes s for HTML and tags the matches FTS5 delimited.
+func markMatches(s string) string {
+	return matchTags.Replace(html.EscapeString(s))
+}
+
+// ftsQuery turns free text into an FTS5 query: every word is quoted (so
+// punctuation can't break the MATCH syntax) and prefix-matched.
+func ftsQuery(q string) string {
+	var terms []string
+	for _, f := range strings.Fields(q) {
+		f = strings.ReplaceAll(f, `"`, `""`)
+		terms = append(terms, `"`+f+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// SearchJobs runs a full-text query over title, company, location and
+// description, best matches first.
+func (s *Store) SearchJobs(ctx context.Context, q string, limit int) ([]JobSearchHit, error) {
+	match := ftsQuery(q)
+	if match == "" {
+		return nil, nil
+	}
+
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT
+			j.id,
+			COALESCE(j.external_id, ''),
+			COALESCE(j.title, ''),
+			COALESCE(j.company, ''),
+			COALESCE(j.location, ''),
+			COALESCE(j.url, ''),
+			COALESCE(j.work_mode, ''),
+			highlight(jobs_fts, 0, ?, ?),
+			highlight(jobs_fts, 1, ?, ?),
+			snippet(jobs_fts, 3, ?, ?, '…', 24),
+			bm25(jobs_fts)
+		FROM jobs_fts
+		JOIN jobs j ON j.id = jobs_fts.rowid
+		WHERE jobs_fts MATCH ?
+		ORDER BY bm25(jobs_fts)
+		LIMIT ?`,
+		matchStart, matchEnd,
+		matchStart, matchEnd,
+		matchStart, matchEnd,
+		match, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []JobSearchHit
+	for rows.Next() {
+		var h JobSearchHit
+		if err := rows.Scan(
+			&h.Job.ID,
+			&h.Job.ExternalID,
+			&h.Job.Title,
+			&h.Job.Company,
+			&h.Job.Location,
+			&h.Job.URL,
+			&h.Job.WorkMode,
+			&h.TitleHighlight,
+			&h.CompanyHighlight,
+			&h.Snippet,
+			&h.Rank,
+		); err != nil {
+			return nil, err
+		}
+		h.TitleHighlight = markMatches(h.TitleHighlight)
+		h.CompanyHighlight = markMatches(h.CompanyHighlight)
+		h.Snippet = markMatches(h.Snippet)
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"jobflow.local/internal/domain"
+)
+
+func TestSearchJobsEscapesHighlights(t *testing.T) {
+	st := newTestStore(t)
+	saveTestJob(t, st, domain.Job{
+		ExternalID:  "search-1",
+		Title:       "Golang <b>Engineer</b>",
+		Company:     "Acme & Co",
+		Description: `Write Golang services. <script>alert("x")</script>`,
+	})
+
+	hits, err := st.SearchJobs(context.Background(), "golang", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(hits))
+	}
+	h := hits[0]
+	if want := "<mark>Golang</mark> &lt;b&gt;Engineer&lt;/b&gt;"; h.TitleHighlight != want {
+		t.Errorf("title highlight = %q, want %q", h.TitleHighlight, want)
+	}
+	if want := "Acme &amp; Co"; h.CompanyHighlight != want {
+		t.Errorf("company highlight = %q, want %q", h.CompanyHighlight, want)
+	}
+	if want := "Write <mark>Golang</mark> services. &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;"; h.Snippet != want {
+		t.Errorf("snippet = %q, want %q", h.Snippet, want)
+	}
+}
+
+func TestFTSQueryQuotesTerms(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"", ""},
+		{"go", `"go"*`},
+		{`senior "go" dev`, `"senior"* """go"""* "dev"*`},
+		{"c++ AND", `"c++"* "AND"*`},
+	}
+	for _, tt := range tests {
+		if got := ftsQuery(tt.in); got != tt.want {
+			t.Errorf("ftsQuery(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -2,19 +2,102 @@ package store
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 // OpenSQLite opens a SQLite DB and enables foreign keys.
 func OpenSQLite(path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", path)
+	// Pragmas in the DSN apply to every pooled connection, not just the
+	// first. The busy timeout lets the background workers and HTTP handlers
+	// wait for each other's writes instead of failing with SQLITE_BUSY.
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, err
 	}
-	if _, err := db.Exec("PRAGMA foreign_keys = ON;"); err != nil {
+	if err := db.Ping(); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
 	return db, nil
 }
+
+// isUniqueViolation reports whether err is a write refused by a UNIQUE
+// constraint or index.
+func isUniqueViolation(err error) bool {
+	var se *sqlite.Error
+	return errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// timeArg is the value to store for an optional timestamp. Times are written
+// in UTC: the driver stores a time.Time as its String form, which it can only
+// read back when the zone has a name.
+func timeArg(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// storedTimeLayouts are the forms timestamps were written in: by the driver
+// (time.String, including the unnamed "-0500 -0500" zone of rows written
+// before timeArg), by CURRENT_TIMESTAMP, and as RFC 3339.
+var storedTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999 -0700 -0700",
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+	sqliteTimeLayout,
+}
+
+// nullTime scans a nullable timestamp column in any of storedTimeLayouts,
+// where sql.NullTime only takes what the driver itself could parse.
+type nullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+func (nt *nullTime) Scan(v any) error {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		*nt = nullTime{}
+		return nil
+	case time.Time:
+		*nt = nullTime{Time: v, Valid: true}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a timestamp", v)
+	}
+
+	// time.String adds the monotonic clock reading to times from time.Now.
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	for _, layout := range storedTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			*nt = nullTime{Time: t, Valid: true}
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized timestamp %q", s)
+}
+
+// ptr returns the time, or nil when the column was NULL.
+func (nt nullTime) ptr() *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	t := nt.Time
+	return &t
+}
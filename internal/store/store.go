@@ -1,52 +1,31 @@
 package store
 
 import (
-	"context"
 	"database/sql"
+	"errors"
 )
 
+// ErrNotFound is returned when a lookup by id matches no row.
+var ErrNotFound = errors.New("not found")
+
 type Store struct {
 	DB *sql.DB
+
+	// HoursPerYear annualizes hourly, daily and weekly salaries;
+	// salary.DefaultHoursPerYear when zero.
+	HoursPerYear float64
 }
 
 func New(db *sql.DB) *Store { return &Store{DB: db} }
 
-func (s *Store) Migrate(ctx context.Context) error {
-	_, err := s.DB.ExecContext(ctx, `
-CREATE TABLE IF NOT EXISTS jobs (
-	id INTEGER PRIMARY KEY AUTOINCREMENT,
-	external_id TEXT UNIQUE,
-	title TEXT,
-	company TEXT,
-	location TEXT,
-	url TEXT,
-	work_mode TEXT,
-	salary TEXT,
-	description TEXT,
-	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE IF NOT EXISTS applications (
-	id INTEGER PRIMARY KEY AUTOINCREMENT,
-	job_id INTEGER NOT NULL,
-	status TEXT,
-	outcome TEXT,
-	applied_on TIMESTAMP NULL,
-	interview_time TIMESTAMP NULL,
-	notes TEXT,
-	notion_page_id TEXT,
-	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
-);
-
-CREATE TABLE IF NOT EXISTS contacts (
-	id INTEGER PRIMARY KEY AUTOINCREMENT,
-	job_id INTEGER NOT NULL,
-	name TEXT,
-	email TEXT,
-	role TEXT,
-	notes TEXT,
-	FOREIGN KEY(job_id) REFERENCES jobs(id) ON DELETE CASCADE
-);
-`)
-	return err
+// expectOneRow turns "no rows affected" into ErrNotFound.
+func expectOneRow(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"jobflow.local/internal/domain"
+)
+
+// newTestStore opens a migrated store on a fresh database file.
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "jobflow.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	st := New(db)
+	if err := st.Migrate(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return st
+}
+
+// saveTestJob saves job with a new application and returns both.
+func saveTestJob(t *testing.T, st *Store, job domain.Job) (domain.Job, domain.Application) {
+	t.Helper()
+	app := domain.Application{Stage: "Applied"}
+	if err := st.UpsertJobAndApplication(context.Background(), &job, &app, nil, domain.SourceAPI); err != nil {
+		t.Fatalf("save job: %v", err)
+	}
+	return job, app
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	st := newTestStore(t)
+	if err := st.Migrate(context.Background()); err != nil {
+		t.Fatalf("second migrate: %v", err)
+	}
+}
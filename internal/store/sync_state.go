@@ -0,0 +1,27 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+// GetSyncState returns the value saved under key, or "" if there is none.
+func (s *Store) GetSyncState(ctx context.Context, key string) (string, error) {
+	var v string
+	err := s.DB.QueryRowContext(ctx, `SELECT value FROM sync_state WHERE key = ?`, key).Scan(&v)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return v, err
+}
+
+// SetSyncState saves value under key, replacing any previous value.
+func (s *Store) SetSyncState(ctx context.Context, key, value string) error {
+	_, err := s.DB.ExecContext(ctx, `
+		INSERT INTO sync_state (key, value, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+		key, value,
+	)
+	return err
+}